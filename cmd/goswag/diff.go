@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	diffContext = 3
+
+	// maxDiffCells caps the size of the LCS table. Past it, the diff only
+	// reports the first line that differs, which is still enough for a CI
+	// failure message.
+	maxDiffCells = 4_000_000
+)
+
+// unifiedDiff returns a unified diff from want to got, or "" when both are
+// equal. Implemented in-house to keep the CLI free of extra dependencies.
+func unifiedDiff(name string, want, got []byte) string {
+	if string(want) == string(got) {
+		return ""
+	}
+
+	a := splitLines(string(want))
+	b := splitLines(string(got))
+
+	// trim the common prefix and suffix so the quadratic LCS only runs on
+	// the region that actually changed
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	midA := a[prefix : len(a)-suffix]
+	midB := b[prefix : len(b)-suffix]
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s (committed)\n+++ %s (regenerated)\n", name, name)
+
+	if len(midA)*len(midB) > maxDiffCells {
+		fmt.Fprintf(&out, "@@ first difference at line %d @@\n", prefix+1)
+		if prefix < len(a) {
+			fmt.Fprintf(&out, "-%s\n", a[prefix])
+		}
+		if prefix < len(b) {
+			fmt.Fprintf(&out, "+%s\n", b[prefix])
+		}
+		return out.String()
+	}
+
+	ops := append(equalOps(a[:prefix]), lcsOps(midA, midB)...)
+	ops = append(ops, equalOps(a[len(a)-suffix:])...)
+
+	writeHunks(&out, ops)
+
+	return out.String()
+}
+
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+func equalOps(lines []string) []diffOp {
+	ops := make([]diffOp, 0, len(lines))
+	for _, l := range lines {
+		ops = append(ops, diffOp{kind: ' ', line: l})
+	}
+	return ops
+}
+
+// lcsOps computes the edit script between a and b through the classic
+// longest common subsequence table.
+func lcsOps(a, b []string) []diffOp {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{kind: ' ', line: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{kind: '-', line: a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{kind: '+', line: b[j]})
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{kind: '-', line: a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{kind: '+', line: b[j]})
+	}
+
+	return ops
+}
+
+// writeHunks groups the edit script into @@ hunks with diffContext lines of
+// unchanged context around each change.
+func writeHunks(out *strings.Builder, ops []diffOp) {
+	for start := 0; start < len(ops); {
+		// find the next change
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			return
+		}
+
+		from := max(start-diffContext, 0)
+
+		// extend the hunk while changes are closer than 2*diffContext apart
+		end := start
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+
+			next := end
+			for next < len(ops) && ops[next].kind == ' ' {
+				next++
+			}
+			if next == len(ops) || next-end > 2*diffContext {
+				break
+			}
+			end = next
+		}
+		to := min(end+diffContext, len(ops))
+
+		lineA, lineB := 1, 1
+		for _, op := range ops[:from] {
+			if op.kind != '+' {
+				lineA++
+			}
+			if op.kind != '-' {
+				lineB++
+			}
+		}
+
+		countA, countB := 0, 0
+		for _, op := range ops[from:to] {
+			if op.kind != '+' {
+				countA++
+			}
+			if op.kind != '-' {
+				countB++
+			}
+		}
+
+		// like diff(1), an empty side is addressed as the line before it
+		if countA == 0 {
+			lineA--
+		}
+		if countB == 0 {
+			lineB--
+		}
+
+		fmt.Fprintf(out, "@@ -%d,%d +%d,%d @@\n", lineA, countA, lineB, countB)
+		for _, op := range ops[from:to] {
+			fmt.Fprintf(out, "%c%s\n", op.kind, op.line)
+		}
+
+		start = to
+	}
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		got  string
+		diff string
+	}{
+		{
+			name: "Equal content produces no diff",
+			want: "a\nb\n",
+			got:  "a\nb\n",
+			diff: "",
+		},
+		{
+			name: "Changed line in the middle keeps three lines of context",
+			want: "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			got:  "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			diff: "--- f (committed)\n+++ f (regenerated)\n" +
+				"@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name: "Missing committed file shows every line as added",
+			want: "",
+			got:  "package main\n",
+			diff: "--- f (committed)\n+++ f (regenerated)\n@@ -0,0 +1,1 @@\n+package main\n",
+		},
+		{
+			name: "Distant changes produce separate hunks",
+			want: "a\n1\n2\n3\n4\n5\n6\n7\n8\nb\n",
+			got:  "A\n1\n2\n3\n4\n5\n6\n7\n8\nB\n",
+			diff: "--- f (committed)\n+++ f (regenerated)\n" +
+				"@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n" +
+				"@@ -7,4 +7,4 @@\n 6\n 7\n 8\n-b\n+B\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := unifiedDiff("f", []byte(tt.want), []byte(tt.got))
+			if got != tt.diff {
+				t.Errorf("unifiedDiff() =\n%s\nwant\n%s", got, tt.diff)
+			}
+		})
+	}
+}
+
+func TestUnifiedDiff_LargeInputFallsBackToFirstDifference(t *testing.T) {
+	var a, b strings.Builder
+	for i := 0; i < 3000; i++ {
+		a.WriteString("a\n")
+		b.WriteString("b\n")
+	}
+
+	got := unifiedDiff("f", []byte(a.String()), []byte(b.String()))
+	if !strings.Contains(got, "@@ first difference at line 1 @@\n-a\n+b\n") {
+		t.Errorf("unexpected fallback diff:\n%s", got)
+	}
+}
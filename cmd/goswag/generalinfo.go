@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// generalInfoFileName is the temporary file passed to `swag init -g` when the
+// stub declares general API info of its own.
+const generalInfoFileName = "goswag_general_info.go"
+
+// writeGeneralInfo merges the swag general API info of mainFile (@title,
+// @version...) with the one written by goswag in the stub (the Info and the
+// security schemes set on the wrapper) into a file next to mainFile, because
+// swag only reads the general info of the -g file. The stub comes last, so
+// its info wins over the comments of mainFile.
+// It returns the path of that file, or "" when the stub has no general info
+// and mainFile can be passed to swag as is. The caller removes the file.
+func writeGeneralInfo(mainFile, stubFile string) (string, error) {
+	stubInfo, err := generalInfoComments(stubFile)
+	if err != nil {
+		return "", err
+	}
+
+	if len(stubInfo) == 0 {
+		return "", nil
+	}
+
+	mainInfo, err := generalInfoComments(mainFile)
+	if err != nil {
+		return "", err
+	}
+
+	var content strings.Builder
+	content.WriteString("// Code generated by goswag. DO NOT EDIT.\n\n")
+	content.WriteString("package main\n\n")
+
+	for _, comment := range append(mainInfo, stubInfo...) {
+		content.WriteString(comment + "\n\n")
+	}
+
+	path := filepath.Join(filepath.Dir(mainFile), generalInfoFileName)
+	if err := os.WriteFile(path, []byte(content.String()), 0o644); err != nil {
+		return "", fmt.Errorf("writing %s: %w", path, err)
+	}
+
+	return path, nil
+}
+
+// generalInfoComments returns the comment groups of file holding swag
+// general API info: the ones with @ annotations and no @Router, as swag
+// tells them apart.
+func generalInfoComments(file string) ([]string, error) {
+	if _, err := os.Stat(file); err != nil {
+		return nil, nil
+	}
+
+	f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", file, err)
+	}
+
+	var comments []string
+	for _, group := range f.Comments {
+		if isGeneralInfo(group) {
+			lines := make([]string, 0, len(group.List))
+			for _, c := range group.List {
+				lines = append(lines, c.Text)
+			}
+			comments = append(comments, strings.Join(lines, "\n"))
+		}
+	}
+
+	return comments, nil
+}
+
+func isGeneralInfo(group *ast.CommentGroup) bool {
+	annotated := false
+
+	for _, line := range strings.Split(group.Text(), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || !strings.HasPrefix(fields[0], "@") {
+			continue
+		}
+
+		if strings.EqualFold(fields[0], "@router") {
+			return false
+		}
+
+		annotated = true
+	}
+
+	return annotated
+}
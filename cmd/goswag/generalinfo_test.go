@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteGeneralInfo(t *testing.T) {
+	const mainSrc = `package main
+
+// @title Users API
+// @version 1.0
+func main() {}
+`
+
+	t.Run("Stub without general info — main.go is passed to swag as is", func(t *testing.T) {
+		dir := t.TempDir()
+		mainFile := filepath.Join(dir, "main.go")
+		stubFile := filepath.Join(dir, "goswag.go")
+		writeFile(t, mainFile, mainSrc)
+		writeFile(t, stubFile, "package main\n\n// @Summary Get user\n// @Router /users [get]\nfunc getUser() {}\n")
+
+		got, err := writeGeneralInfo(mainFile, stubFile)
+		if err != nil {
+			t.Fatalf("writeGeneralInfo: %v", err)
+		}
+		if got != "" {
+			t.Errorf("writeGeneralInfo = %q; want no file", got)
+		}
+	})
+
+	t.Run("Stub with security definitions — merged with the general info of main.go", func(t *testing.T) {
+		dir := t.TempDir()
+		mainFile := filepath.Join(dir, "main.go")
+		stubFile := filepath.Join(dir, "goswag.go")
+		writeFile(t, mainFile, mainSrc)
+		writeFile(t, stubFile, "package main\n\n"+
+			"// @securityDefinitions.basic Basic\n\n"+
+			"// @Security Basic\n// @Router /users [get]\nfunc getUser() {}\n")
+
+		got, err := writeGeneralInfo(mainFile, stubFile)
+		if err != nil {
+			t.Fatalf("writeGeneralInfo: %v", err)
+		}
+		if want := filepath.Join(dir, generalInfoFileName); got != want {
+			t.Fatalf("writeGeneralInfo = %q; want %q", got, want)
+		}
+
+		content, err := os.ReadFile(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := "// Code generated by goswag. DO NOT EDIT.\n\npackage main\n\n" +
+			"// @title Users API\n// @version 1.0\n\n" +
+			"// @securityDefinitions.basic Basic\n\n"
+		if string(content) != want {
+			t.Errorf("content = %q; want %q", content, want)
+		}
+	})
+}
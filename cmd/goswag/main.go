@@ -7,10 +7,21 @@
 //     the annotated stub
 //  3. `swag fmt -d <input>/`       — formats the annotations in place
 //
+// The stubs of named specs, written by GenerateSwagger in folders of the
+// input directory and recorded in its goswag.specs, get a
+// `swag init --instanceName` run of their own.
+//
 // If `swag` is not on PATH, the CLI installs it automatically (it's a hard
 // dependency anyway). All paths default to the convention documented in
 // the README, so `goswag docs` with no flags works for the recommended
 // project layout.
+//
+// With --native the swag steps are skipped entirely: the user's stub
+// generator is told (through an environment variable) to also write an
+// OpenAPI 3.1 document, built by goswag itself, into the output directory.
+//
+// `goswag routes` runs the stub generator the same way, but tells it to
+// write the route manifest instead of the stub, and prints it.
 package main
 
 import (
@@ -19,12 +30,16 @@ import (
 	"fmt"
 	"go/parser"
 	"go/token"
+	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime/debug"
 	"strconv"
 	"strings"
+
+	"github.com/diegoclair/goswag/internal/generator"
+	"github.com/diegoclair/goswag/internal/static"
 )
 
 const (
@@ -47,6 +62,11 @@ func main() {
 			fmt.Fprintln(os.Stderr, "goswag: "+err.Error())
 			os.Exit(1)
 		}
+	case "routes":
+		if err := runRoutes(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, "goswag: "+err.Error())
+			os.Exit(1)
+		}
 	case "version", "-v", "--version":
 		printVersion()
 	case "help", "-h", "--help":
@@ -64,6 +84,32 @@ type docsConfig struct {
 	pdl           int
 	parseInternal bool
 	skipFormat    bool
+	native        bool
+	check         bool
+	dryRun        bool
+	audience      string
+	static        bool
+
+	// packageName is passed to swag as --packageName when set; empty keeps
+	// swag's default (the output folder name). --check uses it because it
+	// generates into a temp folder whose name must not leak into docs.go.
+	packageName string
+}
+
+// audiences returns the audiences documented each into a folder of their
+// own, when --audience is a comma-separated list, or nil.
+func (cfg docsConfig) audiences() []string {
+	return generator.SplitAudiences(cfg.audience)
+}
+
+// generatorEnv returns the KEY=value entries telling the user's stub
+// generator how to run: env, plus the selected audience.
+func (cfg docsConfig) generatorEnv(env ...string) []string {
+	if cfg.audience != "" {
+		env = append(env, generator.AudienceEnv+"="+cfg.audience)
+	}
+
+	return env
 }
 
 func runDocs(args []string) error {
@@ -77,6 +123,11 @@ func runDocs(args []string) error {
 	fs.IntVar(&cfg.pdl, "pdl", pdlAuto, "swag --pdl (0..3); default auto-detects from imports in the generated stub")
 	fs.BoolVar(&cfg.parseInternal, "parse-internal", true, "pass --parseInternal to swag init")
 	fs.BoolVar(&cfg.skipFormat, "skip-format", false, "skip the `swag fmt` step at the end")
+	fs.BoolVar(&cfg.native, "native", false, "write an OpenAPI 3.1 document with goswag's built-in emitter instead of running swag")
+	fs.BoolVar(&cfg.dryRun, "dry-run", false, "print the effective responses of every route, defaults included, without writing anything")
+	fs.StringVar(&cfg.audience, "audience", "", "document only the routes of this audience, the ones declaring it and the ones without audiences; a comma-separated list documents each audience into a folder of --output")
+	fs.BoolVar(&cfg.static, "static", false, "build the stub from the source of --input, without running it")
+	fs.BoolVar(&cfg.check, "check", false, "regenerate without touching the committed files and fail with a diff if the stub or spec is stale")
 	fs.Usage = func() {
 		fmt.Fprintln(fs.Output(), "Usage: goswag docs [flags]")
 		fmt.Fprintln(fs.Output())
@@ -85,6 +136,28 @@ func runDocs(args []string) error {
 		fmt.Fprintln(fs.Output(), "  2. swag init                 (generates the OpenAPI spec)")
 		fmt.Fprintln(fs.Output(), "  3. swag fmt                  (formats annotations in place)")
 		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Named specs, declared with Spec on groups, are written to folders of --input")
+		fmt.Fprintln(fs.Output(), "and get a swag init --instanceName run of their own, in the same --output.")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "With --native, steps 2 and 3 are replaced by goswag's built-in OpenAPI 3.1")
+		fmt.Fprintln(fs.Output(), "emitter, which writes openapi.json and openapi.yaml into --output.")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "With --dry-run, nothing is written: the effective responses of every route,")
+		fmt.Fprintln(fs.Output(), "the default ones included, are printed instead.")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "With --audience, only the routes of that audience are documented. With a")
+		fmt.Fprintln(fs.Output(), "comma-separated list, e.g. --audience public,internal, each audience gets its")
+		fmt.Fprintln(fs.Output(), "stub in a folder of --input and its spec in the same folder of --output.")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "With --static, step 1 doesn't run the code: the goswag calls are found in the")
+		fmt.Fprintln(fs.Output(), "source of --input and of the packages of the module it uses. Bodies are")
+		fmt.Fprintln(fs.Output(), "documented from their types; Params, ReadForm and Example need values and")
+		fmt.Fprintln(fs.Output(), "are skipped with a warning. It can't be combined with --native.")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "With --check, the same pipeline runs against a scratch copy; the committed")
+		fmt.Fprintln(fs.Output(), "stub and spec are left untouched and the command fails with a diff when")
+		fmt.Fprintln(fs.Output(), "they are out of date. Use it in CI to enforce that docs are regenerated.")
+		fmt.Fprintln(fs.Output())
 		fmt.Fprintln(fs.Output(), "Flags:")
 		fs.PrintDefaults()
 		fmt.Fprintln(fs.Output())
@@ -104,42 +177,177 @@ func runDocs(args []string) error {
 		return err
 	}
 
+	if cfg.static && cfg.native {
+		return errors.New("--static can't be combined with --native: the OpenAPI document is built from values of the body types")
+	}
+
+	if cfg.dryRun && cfg.audiences() != nil {
+		return errors.New("--dry-run documents a single audience, pass one to --audience")
+	}
+
 	mainFile := filepath.Join(cfg.input, "main.go")
 	if _, err := os.Stat(mainFile); err != nil {
 		return fmt.Errorf("input main.go not found at %s — pass --input to point at the right directory", mainFile)
 	}
 
+	if cfg.dryRun {
+		return runDryRun(cfg, mainFile)
+	}
+
+	if cfg.check {
+		return runCheck(cfg, mainFile)
+	}
+
+	if err := generateDocs(cfg, mainFile); err != nil {
+		return err
+	}
+
+	fmt.Println("=====> goswag: done")
+	return nil
+}
+
+// generateDocs runs the generation pipeline (stub + spec) described in the
+// package documentation.
+func generateDocs(cfg docsConfig, mainFile string) error {
+	if cfg.native {
+		return runNativeDocs(cfg, mainFile)
+	}
+
 	if err := ensureSwag(); err != nil {
 		return err
 	}
 
-	fmt.Printf("=====> goswag: generating stub (go run %s)\n", mainFile)
-	if err := run(cfg.input, "go", "run", "main.go"); err != nil {
-		return fmt.Errorf("go run failed: %w", err)
+	if cfg.static {
+		fmt.Printf("=====> goswag: generating stub from the source of %s\n", cfg.input)
+		if err := generateStatic(cfg); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("=====> goswag: generating stub (go run %s)\n", mainFile)
+		if err := runWithEnv(cfg.input, cfg.generatorEnv(), "go", "run", "main.go"); err != nil {
+			return fmt.Errorf("go run failed: %w", err)
+		}
 	}
 
-	pdl := cfg.pdl
-	autodetected := false
-	if pdl == pdlAuto {
-		detected, reason, err := detectPDL(cfg.input)
-		if err != nil {
-			// Detection failure is not fatal — fall back to the conservative
-			// "include external models" level which works for almost every
-			// realistic project.
-			fmt.Fprintf(os.Stderr, "goswag: pdl autodetect failed (%v), falling back to --pdl=1\n", err)
-			pdl = 1
-		} else {
-			pdl = detected
-			fmt.Printf("=====> goswag: auto-detected --pdl=%d (%s)\n", pdl, reason)
+	audiences := cfg.audiences()
+	if audiences == nil {
+		if err := swagDocs(cfg, mainFile, ""); err != nil {
+			return err
+		}
+	}
+
+	for _, audience := range audiences {
+		audienceCfg := cfg
+		audienceCfg.output = filepath.Join(cfg.output, audience)
+		if cfg.packageName != "" {
+			audienceCfg.packageName = filepath.Base(audienceCfg.output)
+		}
+
+		if err := swagDocs(audienceCfg, mainFile, audience); err != nil {
+			return err
+		}
+	}
+
+	if !cfg.skipFormat {
+		fmt.Printf("=====> goswag: running swag fmt on %s\n", cfg.input)
+		if err := run("", "swag", "fmt", "-d", cfg.input); err != nil {
+			return fmt.Errorf("swag fmt failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// swagDocs runs swag init for the stub of audience, or the default one when
+// it is "", and for the stubs of its named specs. The stub of an audience is
+// in a folder of the input directory, which is excluded from the search with
+// the other audiences, the general info still coming from mainFile.
+func swagDocs(cfg docsConfig, mainFile, audience string) error {
+	stubDir := filepath.Join(cfg.input, audience)
+
+	pdl, autodetected := resolvePDL(cfg, stubDir)
+
+	generalInfoFile, err := writeGeneralInfo(mainFile, filepath.Join(stubDir, "goswag.go"))
+	if err != nil {
+		return err
+	}
+	if generalInfoFile != "" {
+		defer os.Remove(generalInfoFile)
+		mainFile = generalInfoFile
+	}
+
+	stubs, err := specStubs(stubDir)
+	if err != nil {
+		return err
+	}
+
+	var excluded []string
+	args := []string{"-g", mainFile}
+	if audience != "" {
+		args = append(args, "-d", "./,"+stubDir)
+		excluded = append(excluded, filepath.Clean(cfg.input))
+	}
+
+	// swag searches the whole project, the routes of named specs
+	// must not end up in the default one
+	for _, stub := range stubs {
+		excluded = append(excluded, stub.dir)
+	}
+	if len(excluded) > 0 {
+		args = append(args, "--exclude", strings.Join(excluded, ","))
+	}
+
+	if audience != "" {
+		fmt.Printf("=====> goswag: running swag init for the %s audience -> %s\n", audience, cfg.output)
+	} else {
+		fmt.Printf("=====> goswag: running swag init -> %s\n", cfg.output)
+	}
+	if err := swagInit(cfg, pdl, autodetected, args...); err != nil {
+		return err
+	}
+
+	for _, stub := range stubs {
+		pdl, autodetected := resolvePDL(cfg, stub.dir)
+
+		fmt.Printf("=====> goswag: running swag init for the %s spec -> %s\n", stub.instanceName, cfg.output)
+		if err := swagInit(cfg, pdl, autodetected, "-g", "goswag.go", "-d", stub.dir, "--instanceName", stub.instanceName); err != nil {
+			return err
 		}
-		autodetected = true
 	}
 
-	swagArgs := []string{"init", "--pdl", strconv.Itoa(pdl), "-g", mainFile, "-o", cfg.output}
+	return nil
+}
+
+// resolvePDL returns the --pdl passed to swag for the stub in dir, and
+// whether it was auto-detected.
+func resolvePDL(cfg docsConfig, dir string) (int, bool) {
+	if cfg.pdl != pdlAuto {
+		return cfg.pdl, false
+	}
+
+	pdl, reason, err := detectPDL(dir)
+	if err != nil {
+		// Detection failure is not fatal — fall back to the conservative
+		// "include external models" level which works for almost every
+		// realistic project.
+		fmt.Fprintf(os.Stderr, "goswag: pdl autodetect failed (%v), falling back to --pdl=1\n", err)
+		return 1, true
+	}
+
+	fmt.Printf("=====> goswag: auto-detected --pdl=%d (%s)\n", pdl, reason)
+	return pdl, true
+}
+
+// swagInit runs swag init into cfg.output with the given extra args.
+func swagInit(cfg docsConfig, pdl int, autodetected bool, args ...string) error {
+	swagArgs := append([]string{"init", "--pdl", strconv.Itoa(pdl), "-o", cfg.output}, args...)
 	if cfg.parseInternal {
 		swagArgs = append(swagArgs, "--parseInternal")
 	}
-	fmt.Printf("=====> goswag: running swag init -> %s\n", cfg.output)
+	if cfg.packageName != "" {
+		swagArgs = append(swagArgs, "--packageName", cfg.packageName)
+	}
+
 	if err := run("", "swag", swagArgs...); err != nil {
 		// swag init's error output is the typical signal a user gets that
 		// they need a higher --pdl (e.g. a dep with @Router annotations on
@@ -153,14 +361,219 @@ func runDocs(args []string) error {
 		return fmt.Errorf("swag init failed: %w", err)
 	}
 
-	if !cfg.skipFormat {
-		fmt.Printf("=====> goswag: running swag fmt on %s\n", cfg.input)
-		if err := run("", "swag", "fmt", "-d", cfg.input); err != nil {
-			return fmt.Errorf("swag fmt failed: %w", err)
+	return nil
+}
+
+// runDryRun runs the user's stub generator in dry-run mode, so it prints the
+// effective responses of every route instead of writing the stub.
+func runDryRun(cfg docsConfig, mainFile string) error {
+	if cfg.static {
+		fmt.Printf("=====> goswag: printing the effective responses (source of %s)\n", cfg.input)
+		return generateStatic(cfg, generator.WithDryRun(os.Stdout))
+	}
+
+	fmt.Printf("=====> goswag: printing the effective responses (go run %s)\n", mainFile)
+	env := cfg.generatorEnv(generator.DryRunEnv + "=1")
+	if err := runWithEnv(cfg.input, env, "go", "run", "main.go"); err != nil {
+		return fmt.Errorf("go run failed: %w", err)
+	}
+
+	return nil
+}
+
+// generateStatic builds the goswag instance from the source of the input
+// folder and generates the stub with it, where `go run` would write it.
+func generateStatic(cfg docsConfig, opts ...generator.Option) error {
+	gen, warnings, err := static.Extract(cfg.input)
+	for _, w := range warnings {
+		fmt.Fprintln(os.Stderr, "goswag: "+w.String())
+	}
+	if err != nil {
+		return fmt.Errorf("static extraction failed: %w", err)
+	}
+
+	audience := generator.WithAudience(cfg.audience)
+	if audiences := cfg.audiences(); audiences != nil {
+		audience = generator.WithAudiences(audiences...)
+	}
+
+	return gen.GenerateSwaggerWith(append([]generator.Option{audience}, opts...)...)
+}
+
+// runNativeDocs runs the user's stub generator with the OpenAPI output
+// directory exported, so GenerateSwagger also writes the spec itself.
+func runNativeDocs(cfg docsConfig, mainFile string) error {
+	output, err := filepath.Abs(cfg.output)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("=====> goswag: generating stub and OpenAPI 3.1 spec (go run %s)\n", mainFile)
+	env := cfg.generatorEnv(generator.OpenAPIOutputEnv + "=" + output)
+	if err := runWithEnv(cfg.input, env, "go", "run", "main.go"); err != nil {
+		return fmt.Errorf("go run failed: %w", err)
+	}
+
+	return nil
+}
+
+// runCheck regenerates the stub and the spec and compares them with the
+// committed ones. The spec is written to a temp folder and every .go file
+// under the input folder is restored afterwards, so a check never leaves
+// the working tree modified, even when it removes the stubs of specs that
+// are no longer declared.
+func runCheck(cfg docsConfig, mainFile string) (err error) {
+	snapshot, err := snapshotGoFiles(cfg.input)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if restoreErr := restoreGoFiles(cfg.input, snapshot); restoreErr != nil && err == nil {
+			err = restoreErr
+		}
+	}()
+
+	tmp, err := os.MkdirTemp("", "goswag-check-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	genCfg := cfg
+	genCfg.output = tmp
+	genCfg.packageName = filepath.Base(filepath.Clean(cfg.output))
+	if err := generateDocs(genCfg, mainFile); err != nil {
+		return err
+	}
+
+	var diffs []string
+
+	stubDirs := []string{cfg.input}
+	if audiences := cfg.audiences(); audiences != nil {
+		stubDirs = stubDirs[:0]
+		for _, audience := range audiences {
+			stubDirs = append(stubDirs, filepath.Join(cfg.input, audience))
+		}
+	}
+
+	for _, dir := range stubDirs {
+		stubs, err := specStubs(dir)
+		if err != nil {
+			return err
+		}
+
+		stubFiles := []string{filepath.Join(dir, "goswag.go")}
+		for _, stub := range stubs {
+			stubFiles = append(stubFiles, filepath.Join(stub.dir, "goswag.go"))
+		}
+
+		for _, stubFile := range stubFiles {
+			regenerated, err := os.ReadFile(stubFile)
+			if err != nil {
+				return fmt.Errorf("reading regenerated stub: %w", err)
+			}
+			if d := unifiedDiff(stubFile, snapshot[stubFile], regenerated); d != "" {
+				diffs = append(diffs, d)
+			}
+		}
+	}
+
+	// the native documents of named specs are written to folders of the output
+	err = filepath.WalkDir(tmp, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(tmp, path)
+		if err != nil {
+			return err
+		}
+
+		committedFile := filepath.Join(cfg.output, rel)
+		committed, _ := os.ReadFile(committedFile) // a missing file diffs against empty
+		regenerated, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if d := unifiedDiff(committedFile, committed, regenerated); d != "" {
+			diffs = append(diffs, d)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(diffs) > 0 {
+		for _, d := range diffs {
+			fmt.Fprintln(os.Stderr, d)
+		}
+		return fmt.Errorf("docs are out of date (%d file(s) differ) — run `goswag docs` and commit the result", len(diffs))
+	}
+
+	fmt.Println("=====> goswag: docs are up to date")
+	return nil
+}
+
+// snapshotGoFiles reads every .go file under dir, and the specsFile
+// record of the named specs, keyed by path.
+func snapshotGoFiles(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".go" && d.Name() != specsFile {
+			return err
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[path] = content
+
+		return nil
+	})
+
+	return files, err
+}
+
+// restoreGoFiles writes back the snapshot taken by snapshotGoFiles, the
+// files removed since included, and removes the files that did not exist
+// when it was taken.
+func restoreGoFiles(dir string, snapshot map[string][]byte) error {
+	current, err := snapshotGoFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	for path, content := range current {
+		original, existed := snapshot[path]
+		if !existed {
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+			continue
+		}
+		if string(original) != string(content) {
+			if err := os.WriteFile(path, original, 0o644); err != nil {
+				return err
+			}
+		}
+	}
+
+	for path, original := range snapshot {
+		if _, ok := current[path]; ok {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, original, 0o644); err != nil {
+			return err
 		}
 	}
 
-	fmt.Println("=====> goswag: done")
 	return nil
 }
 
@@ -268,8 +681,17 @@ func ensureSwag() error {
 }
 
 func run(dir, name string, args ...string) error {
+	return runWithEnv(dir, nil, name, args...)
+}
+
+// runWithEnv is run with extra KEY=value entries appended to the inherited
+// environment.
+func runWithEnv(dir string, env []string, name string, args ...string) error {
 	cmd := exec.Command(name, args...)
 	cmd.Dir = dir
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
@@ -292,11 +714,16 @@ Usage:
   goswag <command> [flags]
 
 Commands:
-  docs       Run the full swagger pipeline (go run + swag init + swag fmt)
+  docs       Run the full swagger pipeline (go run + swag init + swag fmt),
+             or go run + the built-in OpenAPI 3.1 emitter with --native,
+             or read the source instead of running it with --static
+  routes     List the documented routes as a table, or as a JSON
+             manifest with --json, filtered by --tag, --method or
+             --path-prefix
   version    Print the installed CLI version
   help       Show this message
 
-Run "goswag docs --help" for command-specific flags.
+Run "goswag docs --help" or "goswag routes --help" for command-specific flags.
 
 Updating:
   CLI:  go install github.com/diegoclair/goswag/cmd/goswag@latest
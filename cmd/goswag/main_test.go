@@ -3,7 +3,10 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+
+	"github.com/diegoclair/goswag/internal/generator"
 )
 
 func TestIsStdlibImport(t *testing.T) {
@@ -162,6 +165,59 @@ func TestReadModulePath_WalksUpwards(t *testing.T) {
 	}
 }
 
+func TestSnapshotAndRestoreGoFiles(t *testing.T) {
+	dir := t.TempDir()
+	mainFile := filepath.Join(dir, "main.go")
+	stubFile := filepath.Join(dir, "goswag.go")
+	writeFile(t, mainFile, "package main\n")
+	writeFile(t, filepath.Join(dir, "notes.txt"), "untouched")
+
+	specStub := filepath.Join(dir, "v1", "goswag.go")
+	specsFile := filepath.Join(dir, "goswag.specs")
+	if err := os.Mkdir(filepath.Dir(specStub), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, specStub, "package v1\n")
+	writeFile(t, specsFile, "v1\n")
+
+	snapshot, err := snapshotGoFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// simulate a generation run: main.go reformatted, stub created
+	writeFile(t, mainFile, "package main // formatted\n")
+	writeFile(t, stubFile, "package main\n")
+
+	// and the v1 spec no longer declared
+	if err := os.RemoveAll(filepath.Dir(specStub)); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(specsFile); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := restoreGoFiles(dir, snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(mainFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "package main\n" {
+		t.Errorf("main.go = %q; want original content restored", got)
+	}
+	if _, err := os.Stat(stubFile); !os.IsNotExist(err) {
+		t.Errorf("goswag.go should be removed since it did not exist in the snapshot (err = %v)", err)
+	}
+	for _, path := range []string{specStub, specsFile} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s should be restored since it existed in the snapshot (err = %v)", path, err)
+		}
+	}
+}
+
 // --- helpers ---
 
 func writeFile(t *testing.T, path, content string) {
@@ -191,3 +247,39 @@ func containsSubstring(haystack, needle string) bool {
 	}
 	return false
 }
+
+func TestDocsConfig_generatorEnv(t *testing.T) {
+	if got := (docsConfig{}).generatorEnv("A=1"); !reflect.DeepEqual(got, []string{"A=1"}) {
+		t.Errorf("generatorEnv without audience = %q; want [A=1]", got)
+	}
+
+	want := []string{"A=1", generator.AudienceEnv + "=internal"}
+	if got := (docsConfig{audience: "internal"}).generatorEnv("A=1"); !reflect.DeepEqual(got, want) {
+		t.Errorf("generatorEnv = %q; want %q", got, want)
+	}
+}
+
+func TestRunDocs_staticWithNative(t *testing.T) {
+	err := runDocs([]string{"--static", "--native"})
+	if err == nil || !containsSubstring(err.Error(), "--static can't be combined with --native") {
+		t.Errorf("runDocs(--static --native) = %v; want the flags conflict error", err)
+	}
+}
+
+func TestRunDocs_dryRunWithAudiences(t *testing.T) {
+	err := runDocs([]string{"--dry-run", "--audience", "public,internal"})
+	if err == nil || !containsSubstring(err.Error(), "--dry-run documents a single audience") {
+		t.Errorf("runDocs(--dry-run --audience public,internal) = %v; want the flags conflict error", err)
+	}
+}
+
+func TestDocsConfig_audiences(t *testing.T) {
+	if got := (docsConfig{audience: "internal"}).audiences(); got != nil {
+		t.Errorf("audiences of a single audience = %q; want nil", got)
+	}
+
+	want := []string{"public", "internal"}
+	if got := (docsConfig{audience: "public,internal"}).audiences(); !reflect.DeepEqual(got, want) {
+		t.Errorf("audiences = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/diegoclair/goswag/internal/generator"
+	"github.com/diegoclair/goswag/internal/static"
+)
+
+type routesConfig struct {
+	input    string
+	audience string
+	static   bool
+	json     bool
+
+	// filters, a route is listed when it matches all of them
+	tag        string
+	method     string
+	pathPrefix string
+}
+
+func runRoutes(args []string, out io.Writer) error {
+	cfg := routesConfig{}
+
+	fs := flag.NewFlagSet("routes", flag.ContinueOnError)
+	fs.StringVar(&cfg.input, "input", "./goswag", "directory containing the main.go that calls GenerateSwagger()")
+	fs.StringVar(&cfg.input, "i", "./goswag", "shorthand for --input")
+	fs.StringVar(&cfg.audience, "audience", "", "list only the routes of this audience, the ones declaring it and the ones without audiences")
+	fs.BoolVar(&cfg.static, "static", false, "read the routes from the source of --input, without running it")
+	fs.BoolVar(&cfg.json, "json", false, "print the manifest as JSON instead of a table")
+	fs.StringVar(&cfg.tag, "tag", "", "list only the routes with this tag")
+	fs.StringVar(&cfg.method, "method", "", "list only the routes of this HTTP method")
+	fs.StringVar(&cfg.pathPrefix, "path-prefix", "", "list only the routes whose path starts with this prefix")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: goswag routes [flags]")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Lists the documented routes: go run <input>/main.go is told to write the route")
+		fmt.Fprintln(fs.Output(), "manifest instead of the stub, which is left untouched. With --static the")
+		fmt.Fprintln(fs.Output(), "routes are read from the source instead, like goswag docs --static.")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "With --json the manifest is printed as is, after the filters are applied:")
+		fmt.Fprintln(fs.Output(), "method, path, handler, tags, params, body types, responses and the file:line")
+		fmt.Fprintln(fs.Output(), "each route is registered at, under a version number.")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Flags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	mainFile := filepath.Join(cfg.input, "main.go")
+	if _, err := os.Stat(mainFile); err != nil {
+		return fmt.Errorf("input main.go not found at %s — pass --input to point at the right directory", mainFile)
+	}
+
+	manifest, err := loadManifest(cfg)
+	if err != nil {
+		return err
+	}
+
+	manifest.Routes = filterRoutes(manifest.Routes, cfg)
+
+	if cfg.json {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(manifest)
+	}
+
+	return printRoutes(out, manifest.Routes)
+}
+
+// loadManifest gets the route manifest from the user's stub generator, or
+// from its source with --static.
+func loadManifest(cfg routesConfig) (generator.Manifest, error) {
+	var manifest generator.Manifest
+
+	data, err := manifestData(cfg)
+	if err != nil {
+		return manifest, err
+	}
+
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("reading the route manifest: %w", err)
+	}
+
+	if manifest.Version != generator.ManifestVersion {
+		return manifest, fmt.Errorf("the route manifest is version %d, this goswag reads version %d: install the goswag version of your go.mod", manifest.Version, generator.ManifestVersion)
+	}
+
+	return manifest, nil
+}
+
+func manifestData(cfg routesConfig) ([]byte, error) {
+	if cfg.static {
+		gen, warnings, err := static.Extract(cfg.input)
+		for _, w := range warnings {
+			fmt.Fprintln(os.Stderr, "goswag: "+w.String())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("static extraction failed: %w", err)
+		}
+
+		var buf bytes.Buffer
+		if err := gen.GenerateSwaggerWith(generator.WithManifest(&buf), generator.WithAudience(cfg.audience)); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	}
+
+	tmp, err := os.MkdirTemp("", "goswag-routes-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	file := filepath.Join(tmp, "manifest.json")
+	env := docsConfig{audience: cfg.audience}.generatorEnv(generator.ManifestEnv + "=" + file)
+
+	// the output of the user's code must not get mixed with the listing
+	cmd := exec.Command("go", "run", "main.go")
+	cmd.Dir = cfg.input
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("go run failed: %w", err)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("no route manifest written by %s, does it call GenerateSwagger? %w", filepath.Join(cfg.input, "main.go"), err)
+	}
+
+	return data, nil
+}
+
+// filterRoutes returns the routes matching every filter of cfg. Tags and
+// methods are compared ignoring case.
+func filterRoutes(routes []generator.ManifestRoute, cfg routesConfig) []generator.ManifestRoute {
+	filtered := []generator.ManifestRoute{}
+
+	for _, r := range routes {
+		if cfg.method != "" && !strings.EqualFold(r.Method, cfg.method) {
+			continue
+		}
+
+		if cfg.pathPrefix != "" && !strings.HasPrefix(r.Path, cfg.pathPrefix) {
+			continue
+		}
+
+		if cfg.tag != "" && !hasTag(r.Tags, cfg.tag) {
+			continue
+		}
+
+		filtered = append(filtered, r)
+	}
+
+	return filtered
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// printRoutes prints the routes as a table. Handlers are shown without the
+// path of their package, which the source already points at.
+func printRoutes(out io.Writer, routes []generator.ManifestRoute) error {
+	if len(routes) == 0 {
+		_, err := fmt.Fprintln(out, "no routes")
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tPATH\tHANDLER\tTAGS\tSOURCE")
+
+	for _, r := range routes {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Method, r.Path, shortHandler(r.Handler), strings.Join(r.Tags, ","), r.Source)
+	}
+
+	return w.Flush()
+}
+
+// shortHandler strips the package path of a handler name, keeping its
+// package name: handlers.(*Handler).getUser.
+func shortHandler(name string) string {
+	if name == "" {
+		return ""
+	}
+
+	return path.Base(name)
+}
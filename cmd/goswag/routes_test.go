@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/diegoclair/goswag/internal/generator"
+)
+
+var manifestRoutes = []generator.ManifestRoute{
+	{Method: "GET", Path: "/health", Handler: "main.health", Source: "goswag/main.go:10"},
+	{Method: "GET", Path: "/api/users", Handler: "example.com/app/handlers.(*Handler).ListUsers", Tags: []string{"users"}, Source: "routes/routes.go:20"},
+	{Method: "POST", Path: "/api/users", Tags: []string{"users", "admin"}},
+	{Method: "GET", Path: "/api/orders", Tags: []string{"orders"}},
+}
+
+func TestFilterRoutes(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   routesConfig
+		paths []string
+	}{
+		{name: "no filter", cfg: routesConfig{}, paths: []string{"GET /health", "GET /api/users", "POST /api/users", "GET /api/orders"}},
+		{name: "tag ignoring case", cfg: routesConfig{tag: "Admin"}, paths: []string{"POST /api/users"}},
+		{name: "method ignoring case", cfg: routesConfig{method: "post"}, paths: []string{"POST /api/users"}},
+		{name: "path prefix", cfg: routesConfig{pathPrefix: "/api/"}, paths: []string{"GET /api/users", "POST /api/users", "GET /api/orders"}},
+		{name: "every filter", cfg: routesConfig{tag: "users", method: "GET", pathPrefix: "/api"}, paths: []string{"GET /api/users"}},
+		{name: "no match", cfg: routesConfig{tag: "billing"}, paths: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths := []string{}
+			for _, r := range filterRoutes(manifestRoutes, tt.cfg) {
+				paths = append(paths, r.Method+" "+r.Path)
+			}
+
+			if !reflect.DeepEqual(paths, tt.paths) {
+				t.Errorf("filterRoutes = %q; want %q", paths, tt.paths)
+			}
+		})
+	}
+}
+
+func TestPrintRoutes(t *testing.T) {
+	var out bytes.Buffer
+	if err := printRoutes(&out, manifestRoutes[:3]); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "METHOD  PATH        HANDLER                        TAGS         SOURCE\n" +
+		"GET     /health     main.health                                 goswag/main.go:10\n" +
+		"GET     /api/users  handlers.(*Handler).ListUsers  users        routes/routes.go:20\n" +
+		"POST    /api/users                                 users,admin  \n"
+	if out.String() != want {
+		t.Errorf("printRoutes =\n%s\nwant\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	if err := printRoutes(&out, nil); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "no routes\n" {
+		t.Errorf("printRoutes without routes = %q; want %q", out.String(), "no routes\n")
+	}
+}
+
+func TestRunRoutes_static(t *testing.T) {
+	input := filepath.Join("..", "..", "internal", "static", "testdata", "app", "goswag")
+
+	var out bytes.Buffer
+	if err := runRoutes([]string{"--static", "--json", "-i", input, "--method", "post"}, &out); err != nil {
+		t.Fatalf("runRoutes: %v", err)
+	}
+
+	var manifest generator.Manifest
+	if err := json.Unmarshal(out.Bytes(), &manifest); err != nil {
+		t.Fatalf("decoding the manifest: %v\n%s", err, out.String())
+	}
+
+	if manifest.Version != generator.ManifestVersion || len(manifest.Routes) != 2 {
+		t.Fatalf("manifest = %+v; want the two POST routes only", manifest)
+	}
+
+	r := manifest.Routes[0]
+	if r.Path != "/api/users" || r.Handler != "example.com/app/handlers.(*Handler).CreateUser" || r.Source != "routes/routes.go:29" {
+		t.Errorf("route = %+v; want POST /api/users by CreateUser, registered at routes/routes.go:29", r)
+	}
+}
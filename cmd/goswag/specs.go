@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// generatedHeader is the first line of the stubs written by GenerateSwagger.
+const generatedHeader = "// Code generated by goswag. DO NOT EDIT."
+
+// specStub is the stub of a named spec, written by GenerateSwagger in a
+// folder of the input directory named after the spec.
+type specStub struct {
+	dir          string
+	instanceName string // the package clause of the stub, a valid swag --instanceName
+}
+
+// specsFile is the record of the named specs GenerateSwagger keeps next to
+// the default stub, one name per line.
+const specsFile = "goswag.specs"
+
+// specStubs returns the stubs of the named specs found in input: the ones
+// recorded in its specsFile. The other folders with a stub, like the ones of
+// the audiences, are not named specs.
+func specStubs(input string) ([]specStub, error) {
+	record, err := os.ReadFile(filepath.Join(input, specsFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var stubs []specStub
+	for _, name := range strings.Fields(string(record)) {
+		dir := filepath.Join(input, name)
+		stubFile := filepath.Join(dir, "goswag.go")
+
+		content, err := os.ReadFile(stubFile)
+		if err != nil || !bytes.HasPrefix(content, []byte(generatedHeader)) {
+			continue
+		}
+
+		f, err := parser.ParseFile(token.NewFileSet(), stubFile, content, parser.PackageClauseOnly)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", stubFile, err)
+		}
+
+		stubs = append(stubs, specStub{dir: dir, instanceName: f.Name.Name})
+	}
+
+	return stubs, nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSpecStubs(t *testing.T) {
+	input := t.TempDir()
+	for _, dir := range []string{"admin-api", "v1", "handlers", "empty", "public"} {
+		if err := os.Mkdir(filepath.Join(input, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFile(t, filepath.Join(input, "goswag.go"), generatedHeader+"\n\npackage main\n")
+	writeFile(t, filepath.Join(input, "admin-api", "goswag.go"), generatedHeader+"\n\npackage admin_api\n")
+	writeFile(t, filepath.Join(input, "v1", "goswag.go"), generatedHeader+"\n\npackage v1\n")
+	writeFile(t, filepath.Join(input, "handlers", "goswag.go"), "package handlers\n")
+	writeFile(t, filepath.Join(input, "public", "goswag.go"), generatedHeader+"\n\npackage public\n")
+	writeFile(t, filepath.Join(input, specsFile), "v1\nadmin-api\nhandlers\nremoved\n")
+
+	got, err := specStubs(input)
+	if err != nil {
+		t.Fatalf("specStubs: %v", err)
+	}
+
+	want := []specStub{
+		{dir: filepath.Join(input, "v1"), instanceName: "v1"},
+		{dir: filepath.Join(input, "admin-api"), instanceName: "admin_api"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("specStubs = %+v; want %+v", got, want)
+	}
+}
+
+func TestSpecStubs_withoutRecord(t *testing.T) {
+	input := t.TempDir()
+	if err := os.Mkdir(filepath.Join(input, "public"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(input, "public", "goswag.go"), generatedHeader+"\n\npackage public\n")
+
+	got, err := specStubs(input)
+	if err != nil {
+		t.Fatalf("specStubs: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("specStubs = %+v; want none without %s", got, specsFile)
+	}
+}
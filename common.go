@@ -1,9 +1,105 @@
 package goswag
 
+import "github.com/diegoclair/goswag/models"
+
 const (
 	// These are the types that are used to define the type of the field in the swagger.
 	StringType = "string"
 	IntType    = "int"
 	NumberType = "number"
 	BoolType   = "boolean"
+	// ArrayType is a list of values, whose elements are described with Items.
+	ArrayType = "array"
+)
+
+// Info is the general information of the API, see SetInfo.
+type Info = models.Info
+
+// Contact is the contact information of the API.
+type Contact = models.Contact
+
+// License is the license of the API.
+type License = models.License
+
+// ExternalDocs points to additional documentation.
+type ExternalDocs = models.ExternalDocs
+
+// Tag documents a tag, see Group(...).Tag.
+type Tag = models.Tag
+
+// TagNamer names the default tag of the routes of a group, see SetTagNamer.
+type TagNamer = models.TagNamer
+
+// SecurityScheme describes how clients authenticate: an api key, http basic or
+// bearer auth, or an oauth2 flow.
+type SecurityScheme = models.SecurityScheme
+
+const (
+	// Security scheme types, see SecurityScheme.
+	SecurityAPIKey = models.SecurityAPIKey
+	SecurityBasic  = models.SecurityBasic
+	SecurityBearer = models.SecurityBearer
+	SecurityOAuth2 = models.SecurityOAuth2
+
+	// OAuth2 flows of a SecurityOAuth2 scheme.
+	OAuth2Implicit    = models.OAuth2Implicit
+	OAuth2Password    = models.OAuth2Password
+	OAuth2Application = models.OAuth2Application
+	OAuth2AccessCode  = models.OAuth2AccessCode
 )
+
+// ParamOption sets an optional attribute of a param: enum values, default,
+// ranges, format, example or the items of an array.
+type ParamOption = models.ParamOption
+
+// Enum restricts the param to the given values.
+func Enum(values ...any) ParamOption {
+	return models.Enum(values...)
+}
+
+// Default sets the value used by the server when the param is omitted.
+func Default(value any) ParamOption {
+	return models.Default(value)
+}
+
+// Example sets an example value of the param.
+func Example(value any) ParamOption {
+	return models.Example(value)
+}
+
+// Minimum sets the inclusive lower bound of a numeric param.
+func Minimum(value float64) ParamOption {
+	return models.Minimum(value)
+}
+
+// Maximum sets the inclusive upper bound of a numeric param.
+func Maximum(value float64) ParamOption {
+	return models.Maximum(value)
+}
+
+// MinLength sets the minimum length of a string param.
+func MinLength(length int) ParamOption {
+	return models.MinLength(length)
+}
+
+// MaxLength sets the maximum length of a string param.
+func MaxLength(length int) ParamOption {
+	return models.MaxLength(length)
+}
+
+// Pattern sets the regular expression a string param must match.
+// swag has no attribute for it, so it is only in the native OpenAPI output.
+func Pattern(pattern string) ParamOption {
+	return models.Pattern(pattern)
+}
+
+// Format refines the data type of the param: date-time, uuid, email...
+func Format(format string) ParamOption {
+	return models.Format(format)
+}
+
+// Items sets the data type of the elements of an ArrayType param and how the
+// array is serialized: csv (a,b), multi (?a=1&a=2), ssv, tsv or pipes.
+func Items(dataType, collectionFormat string) ParamOption {
+	return models.Items(dataType, collectionFormat)
+}
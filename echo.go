@@ -1,6 +1,8 @@
 package goswag
 
 import (
+	"io"
+
 	echoWrapper "github.com/diegoclair/goswag/internal/frameworks/echo"
 	"github.com/diegoclair/goswag/models"
 
@@ -9,7 +11,33 @@ import (
 
 type Echo interface {
 	models.EchoGroup
+	// GenerateSwagger writes ./goswag.go and exits the process if it fails.
 	GenerateSwagger()
+	// GenerateSwaggerWith writes the stub file according to the given options
+	// and returns any error to the caller instead of exiting.
+	GenerateSwaggerWith(opts ...GenerateOption) error
+	// Validate checks the route declarations (path params vs placeholders,
+	// duplicated routes, unknown param types, bodies on GET/HEAD) and returns
+	// the findings. GenerateSwaggerWith logs them, or fails with WithStrict.
+	Validate() []Finding
+	// WriteTo writes the content of the stub file (package main) to w.
+	WriteTo(w io.Writer) (int64, error)
+	// GenerateOpenAPI writes an OpenAPI 3.1 document (openapi.json and openapi.yaml)
+	// into dir without needing the swag binary. Named specs get theirs in dir/<spec>.
+	GenerateOpenAPI(dir string) error
+	// AddSecurityScheme registers a security scheme under name, so routes and groups
+	// can require it with Security. See SecurityScheme for the supported types.
+	AddSecurityScheme(name string, scheme SecurityScheme)
+	// SetInfo sets the general information of the API (title, version, host...),
+	// replacing the swag general API comments above main.
+	SetInfo(info Info)
+	// SetSpecInfo sets the general information of the named spec, e.g. the title and version
+	// of the spec of the groups set with Spec("v2"). Specs without it use the one of SetInfo.
+	SetSpecInfo(spec string, info Info)
+	// SetTagNamer replaces how the default tag of the routes of a group is named from the
+	// prefixes of the group and of its parents. By default they are joined without the
+	// leading and trailing slashes: "api/users".
+	SetTagNamer(namer TagNamer)
 	Echo() *echo.Echo
 }
 
@@ -0,0 +1,104 @@
+package goswag
+
+import (
+	"io"
+
+	"github.com/diegoclair/goswag/internal/generator"
+)
+
+// GenerateOption configures GenerateSwaggerWith.
+type GenerateOption = generator.Option
+
+// Logger is the subset of *log.Logger used to report the generation progress.
+type Logger = generator.Logger
+
+// WithOutputDir sets the directory where the stub file is written.
+// It is created if it does not exist. Default: the current directory.
+func WithOutputDir(dir string) GenerateOption {
+	return generator.WithOutputDir(dir)
+}
+
+// WithFileName sets the name of the stub file. Default: goswag.go.
+func WithFileName(name string) GenerateOption {
+	return generator.WithFileName(name)
+}
+
+// WithPackageName sets the package clause of the stub file, so it can live
+// in a package other than main. Default: main.
+func WithPackageName(name string) GenerateOption {
+	return generator.WithPackageName(name)
+}
+
+// WithLogger sets the logger used to report the generation progress.
+// Pass log.New(io.Discard, "", 0) to silence it. Default: log.Default().
+func WithLogger(l Logger) GenerateOption {
+	return generator.WithLogger(l)
+}
+
+// WithStrict makes GenerateSwaggerWith fail with a *ValidationError, before
+// anything is written, when the route declarations have error findings.
+// Without it, the findings are only logged, but the blocking ones.
+func WithStrict() GenerateOption {
+	return generator.WithStrict()
+}
+
+// WithDryRun makes GenerateSwaggerWith write the effective responses of every
+// route to w, the default ones included, instead of writing the stub file.
+func WithDryRun(w io.Writer) GenerateOption {
+	return generator.WithDryRun(w)
+}
+
+// WithAudience makes GenerateSwaggerWith document only the routes of
+// audience: the ones declaring it with Audience, directly or through their
+// group, and the ones without audiences. `goswag docs --audience` sets it
+// too. Default: every route that is not hidden.
+func WithAudience(audience string) GenerateOption {
+	return generator.WithAudience(audience)
+}
+
+// WithAudiences makes GenerateSwaggerWith write one stub per audience, each
+// documenting the routes WithAudience would, in a folder of the output
+// directory named after the audience: public/goswag.go, internal/goswag.go...
+// `goswag docs --audience public,internal` sets it too.
+func WithAudiences(audiences ...string) GenerateOption {
+	return generator.WithAudiences(audiences...)
+}
+
+// WithManifest makes GenerateSwaggerWith write the route manifest to w
+// instead of writing the stub file: every documented route as JSON, with its
+// handler, params, bodies, responses and the file:line it is registered at.
+// `goswag routes` prints it.
+func WithManifest(w io.Writer) GenerateOption {
+	return generator.WithManifest(w)
+}
+
+// Manifest is the machine-readable list of the documented routes written
+// by WithManifest. Its Version is ManifestVersion.
+type Manifest = generator.Manifest
+
+// ManifestRoute is a route of a Manifest.
+type ManifestRoute = generator.ManifestRoute
+
+// ManifestParam is a param of a ManifestRoute.
+type ManifestParam = generator.ManifestParam
+
+// ManifestResponse is a response of a ManifestRoute.
+type ManifestResponse = generator.ManifestResponse
+
+// ManifestVersion is the version of the manifest format, bumped when a field
+// changes meaning or is removed.
+const ManifestVersion = generator.ManifestVersion
+
+// Finding is a problem detected in the route declarations at generation time.
+type Finding = generator.Finding
+
+// Severity tells whether a Finding makes the docs wrong or is only suspicious.
+type Severity = generator.Severity
+
+const (
+	SeverityError   = generator.SeverityError
+	SeverityWarning = generator.SeverityWarning
+)
+
+// ValidationError is returned by GenerateSwaggerWith in strict mode.
+type ValidationError = generator.ValidationError
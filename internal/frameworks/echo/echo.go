@@ -1,6 +1,11 @@
 package echo
 
 import (
+	"io"
+	"log"
+	"time"
+
+	"github.com/diegoclair/goswag/internal/frameworks/shared"
 	"github.com/diegoclair/goswag/internal/generator"
 	"github.com/diegoclair/goswag/models"
 	"github.com/labstack/echo/v4"
@@ -11,6 +16,16 @@ type echoSwagger struct {
 	groups           []*echoGroup
 	routes           []*echoRoute
 	defaultResponses []models.ReturnType
+	security         []generator.SecurityRequirement
+	securitySchemes  map[string]models.SecurityScheme
+	info             models.Info
+	tag              *models.Tag
+	tagNamer         models.TagNamer
+	defaults         generator.RouteDefaults
+	hidden           bool
+	audiences        []string
+	spec             string
+	specInfo         map[string]models.Info
 }
 
 func NewEcho(defaultResponses ...models.ReturnType) *echoSwagger {
@@ -24,8 +39,123 @@ func (s *echoSwagger) Echo() *echo.Echo {
 	return s.e
 }
 
+// GenerateSwagger keeps the original behaviour of exiting the process when
+// the stub can't be written. Use GenerateSwaggerWith to handle the error.
 func (s *echoSwagger) GenerateSwagger() {
-	generator.GenerateSwagger(toGoSwagRoute(s.routes), toGoSwagGroup(s.groups), s.defaultResponses)
+	if err := s.GenerateSwaggerWith(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func (s *echoSwagger) GenerateSwaggerWith(opts ...generator.Option) error {
+	return generator.Generate(s.doc(), opts...)
+}
+
+func (s *echoSwagger) Validate() []generator.Finding {
+	return generator.Validate(s.doc())
+}
+
+func (s *echoSwagger) WriteTo(w io.Writer) (int64, error) {
+	return generator.Write(w, s.doc())
+}
+
+func (s *echoSwagger) GenerateOpenAPI(dir string) error {
+	return generator.GenerateSpecsOpenAPI(s.doc(), dir)
+}
+
+func (s *echoSwagger) doc() generator.Doc {
+	return generator.Doc{
+		Routes:           toGoSwagRoute(s.routes),
+		Groups:           toGoSwagGroup(s.groups),
+		DefaultResponses: s.defaultResponses,
+		Security:         s.security,
+		SecuritySchemes:  s.securitySchemes,
+		Info:             s.info,
+		Tag:              s.tag,
+		TagNamer:         s.tagNamer,
+		Defaults:         s.defaults,
+		Hidden:           s.hidden,
+		Audiences:        s.audiences,
+		Spec:             s.spec,
+		SpecInfo:         s.specInfo,
+	}
+}
+
+func (s *echoSwagger) SetInfo(info models.Info) {
+	s.info = info
+}
+
+func (s *echoSwagger) SetSpecInfo(spec string, info models.Info) {
+	if s.specInfo == nil {
+		s.specInfo = make(map[string]models.Info)
+	}
+
+	s.specInfo[spec] = info
+}
+
+func (s *echoSwagger) SetTagNamer(namer models.TagNamer) {
+	s.tagNamer = namer
+}
+
+func (s *echoSwagger) DefaultResponses(responses ...models.ReturnType) models.EchoGroup {
+	s.defaults.Responses = append(s.defaults.Responses, responses...)
+	return s
+}
+
+func (s *echoSwagger) HeaderParam(name, description, paramType string, required bool, opts ...models.ParamOption) models.EchoGroup {
+	s.defaults.HeaderParams = append(s.defaults.HeaderParams, generator.Param{
+		Name:        name,
+		Description: description,
+		ParamType:   paramType,
+		Required:    required,
+
+		ParamAttributes: models.NewParamAttributes(opts...),
+	})
+
+	return s
+}
+
+func (s *echoSwagger) Accepts(accepts ...string) models.EchoGroup {
+	s.defaults.Accepts = accepts
+	return s
+}
+
+func (s *echoSwagger) Produces(produces ...string) models.EchoGroup {
+	s.defaults.Produces = produces
+	return s
+}
+
+func (s *echoSwagger) Tag(name, description string, externalDocs models.ExternalDocs) models.EchoGroup {
+	s.tag = &models.Tag{Name: name, Description: description, ExternalDocs: externalDocs}
+	return s
+}
+
+func (s *echoSwagger) AddSecurityScheme(name string, scheme models.SecurityScheme) {
+	if s.securitySchemes == nil {
+		s.securitySchemes = make(map[string]models.SecurityScheme)
+	}
+
+	s.securitySchemes[name] = scheme
+}
+
+func (s *echoSwagger) Security(scheme string, scopes ...string) models.EchoGroup {
+	s.security = append(s.security, generator.SecurityRequirement{Scheme: scheme, Scopes: scopes})
+	return s
+}
+
+func (s *echoSwagger) Hidden() models.EchoGroup {
+	s.hidden = true
+	return s
+}
+
+func (s *echoSwagger) Audience(names ...string) models.EchoGroup {
+	s.audiences = append(s.audiences, names...)
+	return s
+}
+
+func (s *echoSwagger) Spec(name string) models.EchoGroup {
+	s.spec = name
+	return s
 }
 
 func (s *echoSwagger) Group(prefix string, m ...echo.MiddlewareFunc) models.EchoGroup {
@@ -43,6 +173,8 @@ func (s *echoSwagger) POST(path string, h echo.HandlerFunc, m ...echo.Middleware
 			Path:     r.Path,
 			Method:   r.Method,
 			FuncName: getFuncName(r.Name),
+			Handler:  handlerName(r.Name),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -59,6 +191,8 @@ func (s *echoSwagger) GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareF
 			Path:     r.Path,
 			Method:   r.Method,
 			FuncName: getFuncName(r.Name),
+			Handler:  handlerName(r.Name),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -75,6 +209,8 @@ func (s *echoSwagger) PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareF
 			Path:     r.Path,
 			Method:   r.Method,
 			FuncName: getFuncName(r.Name),
+			Handler:  handlerName(r.Name),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -91,6 +227,8 @@ func (s *echoSwagger) DELETE(path string, h echo.HandlerFunc, m ...echo.Middlewa
 			Path:     r.Path,
 			Method:   r.Method,
 			FuncName: getFuncName(r.Name),
+			Handler:  handlerName(r.Name),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -107,6 +245,8 @@ func (s *echoSwagger) PATCH(path string, h echo.HandlerFunc, m ...echo.Middlewar
 			Path:     r.Path,
 			Method:   r.Method,
 			FuncName: getFuncName(r.Name),
+			Handler:  handlerName(r.Name),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -123,6 +263,8 @@ func (s *echoSwagger) OPTIONS(path string, h echo.HandlerFunc, m ...echo.Middlew
 			Path:     r.Path,
 			Method:   r.Method,
 			FuncName: getFuncName(r.Name),
+			Handler:  handlerName(r.Name),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -139,6 +281,8 @@ func (s *echoSwagger) HEAD(path string, h echo.HandlerFunc, m ...echo.Middleware
 			Path:     r.Path,
 			Method:   r.Method,
 			FuncName: getFuncName(r.Name),
+			Handler:  handlerName(r.Name),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -152,6 +296,12 @@ type echoGroup struct {
 	groupName string
 	groups    []*echoGroup
 	routes    []*echoRoute
+	security  []generator.SecurityRequirement
+	tag       *models.Tag
+	defaults  generator.RouteDefaults
+	hidden    bool
+	audiences []string
+	spec      string
 }
 
 // Group creates a new sub-group with prefix and optional sub-group-level middleware.
@@ -170,6 +320,8 @@ func (s *echoGroup) POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFu
 			Path:     r.Path,
 			Method:   r.Method,
 			FuncName: getFuncName(r.Name),
+			Handler:  handlerName(r.Name),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -186,6 +338,8 @@ func (s *echoGroup) GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFun
 			Path:     r.Path,
 			Method:   r.Method,
 			FuncName: getFuncName(r.Name),
+			Handler:  handlerName(r.Name),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -202,6 +356,8 @@ func (s *echoGroup) PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFun
 			Path:     r.Path,
 			Method:   r.Method,
 			FuncName: getFuncName(r.Name),
+			Handler:  handlerName(r.Name),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -218,6 +374,8 @@ func (s *echoGroup) DELETE(path string, h echo.HandlerFunc, m ...echo.Middleware
 			Path:     r.Path,
 			Method:   r.Method,
 			FuncName: getFuncName(r.Name),
+			Handler:  handlerName(r.Name),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -234,6 +392,8 @@ func (s *echoGroup) PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareF
 			Path:     r.Path,
 			Method:   r.Method,
 			FuncName: getFuncName(r.Name),
+			Handler:  handlerName(r.Name),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -250,6 +410,8 @@ func (s *echoGroup) OPTIONS(path string, h echo.HandlerFunc, m ...echo.Middlewar
 			Path:     r.Path,
 			Method:   r.Method,
 			FuncName: getFuncName(r.Name),
+			Handler:  handlerName(r.Name),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -266,6 +428,8 @@ func (s *echoGroup) HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFu
 			Path:     r.Path,
 			Method:   r.Method,
 			FuncName: getFuncName(r.Name),
+			Handler:  handlerName(r.Name),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -274,6 +438,59 @@ func (s *echoGroup) HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFu
 	return er
 }
 
+func (s *echoGroup) DefaultResponses(responses ...models.ReturnType) models.EchoGroup {
+	s.defaults.Responses = append(s.defaults.Responses, responses...)
+	return s
+}
+
+func (s *echoGroup) HeaderParam(name, description, paramType string, required bool, opts ...models.ParamOption) models.EchoGroup {
+	s.defaults.HeaderParams = append(s.defaults.HeaderParams, generator.Param{
+		Name:        name,
+		Description: description,
+		ParamType:   paramType,
+		Required:    required,
+
+		ParamAttributes: models.NewParamAttributes(opts...),
+	})
+
+	return s
+}
+
+func (s *echoGroup) Accepts(accepts ...string) models.EchoGroup {
+	s.defaults.Accepts = accepts
+	return s
+}
+
+func (s *echoGroup) Produces(produces ...string) models.EchoGroup {
+	s.defaults.Produces = produces
+	return s
+}
+
+func (s *echoGroup) Tag(name, description string, externalDocs models.ExternalDocs) models.EchoGroup {
+	s.tag = &models.Tag{Name: name, Description: description, ExternalDocs: externalDocs}
+	return s
+}
+
+func (s *echoGroup) Security(scheme string, scopes ...string) models.EchoGroup {
+	s.security = append(s.security, generator.SecurityRequirement{Scheme: scheme, Scopes: scopes})
+	return s
+}
+
+func (s *echoGroup) Hidden() models.EchoGroup {
+	s.hidden = true
+	return s
+}
+
+func (s *echoGroup) Audience(names ...string) models.EchoGroup {
+	s.audiences = append(s.audiences, names...)
+	return s
+}
+
+func (s *echoGroup) Spec(name string) models.EchoGroup {
+	s.spec = name
+	return s
+}
+
 type echoRoute struct {
 	generator.Route
 }
@@ -308,40 +525,152 @@ func (r *echoRoute) Read(value any) models.Swagger {
 	return r
 }
 
+func (r *echoRoute) ReadWithOverrides(value any, overrides map[string]any) models.Swagger {
+	r.Route.Reads = value
+	r.Route.ReadOverrides = overrides
+	return r
+}
+
 func (r *echoRoute) Returns(returns []models.ReturnType) models.Swagger {
 	r.Route.Returns = returns
 	return r
 }
 
-func (r *echoRoute) QueryParam(name, description, paramType string, required bool) models.Swagger {
+func (r *echoRoute) QueryParam(name, description, paramType string, required bool, opts ...models.ParamOption) models.Swagger {
 	r.Route.QueryParams = append(r.Route.QueryParams, generator.Param{
 		Name:        name,
 		Description: description,
 		ParamType:   paramType,
 		Required:    required,
+
+		ParamAttributes: models.NewParamAttributes(opts...),
 	})
 
 	return r
 }
 
-func (r *echoRoute) HeaderParam(name, description, paramType string, required bool) models.Swagger {
+func (r *echoRoute) HeaderParam(name, description, paramType string, required bool, opts ...models.ParamOption) models.Swagger {
 	r.Route.HeaderParams = append(r.Route.HeaderParams, generator.Param{
 		Name:        name,
 		Description: description,
 		ParamType:   paramType,
 		Required:    required,
+
+		ParamAttributes: models.NewParamAttributes(opts...),
 	})
 
 	return r
 }
 
-func (r *echoRoute) PathParam(name, description, paramType string, required bool) models.Swagger {
+func (r *echoRoute) PathParam(name, description, paramType string, required bool, opts ...models.ParamOption) models.Swagger {
 	r.Route.PathParams = append(r.Route.PathParams, generator.Param{
 		Name:        name,
 		Description: description,
 		ParamType:   paramType,
 		Required:    required,
+
+		ParamAttributes: models.NewParamAttributes(opts...),
+	})
+
+	return r
+}
+
+func (r *echoRoute) FormParam(name, description, paramType string, required bool, opts ...models.ParamOption) models.Swagger {
+	r.Route.FormParams = append(r.Route.FormParams, generator.Param{
+		Name:        name,
+		Description: description,
+		ParamType:   paramType,
+		Required:    required,
+
+		ParamAttributes: models.NewParamAttributes(opts...),
 	})
 
 	return r
 }
+
+func (r *echoRoute) FileParam(name, description string, required bool) models.Swagger {
+	return r.FormParam(name, description, generator.FileParamType, required)
+}
+
+func (r *echoRoute) ReadForm(data any) models.Swagger {
+	r.Route.FormParams = append(r.Route.FormParams, generator.FormParams(data)...)
+	return r
+}
+
+func (r *echoRoute) Params(data any) models.Swagger {
+	params := generator.ParamsOf(data, r.Route.Method)
+
+	r.Route.PathParams = append(r.Route.PathParams, params.Path...)
+	r.Route.QueryParams = append(r.Route.QueryParams, params.Query...)
+	r.Route.HeaderParams = append(r.Route.HeaderParams, params.Header...)
+	r.Route.FormParams = append(r.Route.FormParams, params.Form...)
+
+	return r
+}
+
+func (r *echoRoute) Security(scheme string, scopes ...string) models.Swagger {
+	r.Route.Security = append(r.Route.Security, generator.SecurityRequirement{Scheme: scheme, Scopes: scopes})
+	return r
+}
+
+func (r *echoRoute) Public() models.Swagger {
+	r.Route.Public = true
+	return r
+}
+
+func (r *echoRoute) OperationID(id string) models.Swagger {
+	r.Route.OperationID = id
+	return r
+}
+
+func (r *echoRoute) Deprecated(reason string, sunset time.Time) models.Swagger {
+	r.Route.Deprecated = true
+	r.Route.DeprecationReason = reason
+	r.Route.Sunset = sunset
+	return r
+}
+
+func (r *echoRoute) Example(v any) models.Swagger {
+	r.Route.ReadExamples = append(r.Route.ReadExamples, v)
+	return r
+}
+
+func (r *echoRoute) Extension(key string, value any) models.Swagger {
+	if r.Route.Extensions == nil {
+		r.Route.Extensions = make(map[string]any)
+	}
+
+	r.Route.Extensions[key] = value
+	return r
+}
+
+func (r *echoRoute) Hidden() models.Swagger {
+	r.Route.Hidden = true
+	return r
+}
+
+func (r *echoRoute) Audience(names ...string) models.Swagger {
+	r.Route.Audiences = append(r.Route.Audiences, names...)
+	return r
+}
+
+// SetHandler implements shared.StaticRoute.
+func (r *echoRoute) SetHandler(funcName, handler string) {
+	r.Route.FuncName = funcName
+	r.Route.Handler = handler
+}
+
+// SetSource implements shared.StaticRoute.
+func (r *echoRoute) SetSource(source string) {
+	r.Route.Source = source
+}
+
+func (r *echoRoute) SkipDefaultResponses(codes ...int) models.Swagger {
+	if len(codes) == 0 {
+		r.Route.SkipAllDefaultResponses = true
+		return r
+	}
+
+	r.Route.SkipDefaultResponses = append(r.Route.SkipDefaultResponses, codes...)
+	return r
+}
@@ -1,8 +1,13 @@
 package echo
 
 import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/diegoclair/goswag/internal/generator"
 	"github.com/diegoclair/goswag/models"
@@ -14,12 +19,20 @@ import (
 // short name so tests can assert against a stable literal. getFuncName
 // always appends "_<hash>" to disambiguate identically-named handlers
 // across packages; we still validate the prefix matches what the test
-// expects.
+// expects. The handler and the source, which depend on where the test
+// runs from, are checked against the handler and the test file and cleared.
 func normalizeFuncName(t *testing.T, want generator.Route, got generator.Route) generator.Route {
 	t.Helper()
 	if want.FuncName == "" {
 		return got
 	}
+	if !strings.HasSuffix(got.Handler, "."+want.FuncName) {
+		t.Errorf("Handler = %q; want suffix %q", got.Handler, "."+want.FuncName)
+	}
+	if !strings.Contains(got.Source, "echo_test.go:") {
+		t.Errorf("Source = %q; want the test file", got.Source)
+	}
+	got.Handler, got.Source = "", ""
 	prefix := want.FuncName + "_"
 	if !strings.HasPrefix(got.FuncName, prefix) {
 		t.Errorf("FuncName = %q; want prefix %q", got.FuncName, prefix)
@@ -49,6 +62,39 @@ func TestEchoSwagger_Echo(t *testing.T) {
 	})
 }
 
+func TestEchoSwagger_GenerateSwaggerWith(t *testing.T) {
+	t.Run("should write the stub in the given directory and return no error", func(t *testing.T) {
+		dir := t.TempDir()
+		s := NewEcho()
+		s.GET("/test", func(c echo.Context) error { return nil }).Summary("test")
+
+		err := s.GenerateSwaggerWith(
+			generator.WithOutputDir(dir),
+			generator.WithPackageName("docs"),
+			generator.WithLogger(log.New(io.Discard, "", 0)),
+		)
+		assert.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(dir, "goswag.go"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "package docs\n")
+		assert.Contains(t, string(content), "// @Router /test [get]\n")
+	})
+}
+
+func TestEchoSwagger_WriteTo(t *testing.T) {
+	t.Run("should write the stub content to the writer", func(t *testing.T) {
+		s := NewEcho()
+		s.GET("/test", func(c echo.Context) error { return nil }).Summary("test")
+
+		var b strings.Builder
+		n, err := s.WriteTo(&b)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(b.Len()), n)
+		assert.Contains(t, b.String(), "// @Summary test\n")
+	})
+}
+
 func TestGroup(t *testing.T) {
 	type args struct {
 		prefix string
@@ -1017,3 +1063,197 @@ func TestEchoRoute_PathParam(t *testing.T) {
 		})
 	}
 }
+
+func TestEchoRoute_FormParam(t *testing.T) {
+	r := &echoRoute{}
+	got := r.FormParam("name", "Name", "string", true).FileParam("avatar", "Avatar", false)
+	assert.NotNil(t, got)
+
+	assert.Equal(t, []generator.Param{
+		{Name: "name", Description: "Name", ParamType: "string", Required: true},
+		{Name: "avatar", Description: "Avatar", ParamType: generator.FileParamType},
+	}, r.Route.FormParams)
+}
+
+func TestEchoRoute_ReadForm(t *testing.T) {
+	r := &echoRoute{}
+	got := r.ReadForm(&struct {
+		Email string `form:"email" validate:"required"`
+		Page  int    `form:"page"`
+	}{})
+	assert.NotNil(t, got)
+
+	assert.Equal(t, []generator.Param{
+		{Name: "email", ParamType: "string", Required: true},
+		{Name: "page", ParamType: "integer"},
+	}, r.Route.FormParams)
+}
+
+func TestEchoRoute_QueryParam_options(t *testing.T) {
+	r := &echoRoute{}
+	r.QueryParam("status", "Status", "array", false, models.Items("string", "multi"), models.Enum("open", "closed"))
+
+	assert.Equal(t, []generator.Param{
+		{
+			Name: "status", Description: "Status", ParamType: "array",
+			ParamAttributes: models.ParamAttributes{Items: "string", CollectionFormat: "multi", Enum: []any{"open", "closed"}},
+		},
+	}, r.Route.QueryParams)
+}
+
+func TestEchoRoute_Params(t *testing.T) {
+	r := &echoRoute{Route: generator.Route{Method: "GET"}}
+	got := r.Params(struct {
+		ID     string `param:"id"`
+		Page   int    `query:"page"`
+		Filter string `form:"filter"`
+		Token  string `header:"Authorization" validate:"required"`
+	}{})
+	assert.NotNil(t, got)
+
+	assert.Equal(t, []generator.Param{{Name: "id", ParamType: "string", Required: true}}, r.Route.PathParams)
+	assert.Equal(t, []generator.Param{
+		{Name: "page", ParamType: "integer"},
+		{Name: "filter", ParamType: "string"},
+	}, r.Route.QueryParams)
+	assert.Equal(t, []generator.Param{{Name: "Authorization", ParamType: "string", Required: true}}, r.Route.HeaderParams)
+	assert.Nil(t, r.Route.FormParams)
+}
+
+func TestEchoRoute_Security(t *testing.T) {
+	r := &echoRoute{}
+	got := r.Security("OAuth2", "read", "write").Security("ApiKey").Public()
+	assert.NotNil(t, got)
+
+	assert.Equal(t, []generator.SecurityRequirement{
+		{Scheme: "OAuth2", Scopes: []string{"read", "write"}},
+		{Scheme: "ApiKey"},
+	}, r.Route.Security)
+	assert.True(t, r.Route.Public)
+}
+
+func TestEchoSwagger_Security(t *testing.T) {
+	s := NewEcho()
+	s.AddSecurityScheme("Bearer", models.SecurityScheme{Type: models.SecurityBearer})
+	s.Security("Bearer")
+
+	g := s.Group("/admin").Security("OAuth2", "admin")
+	g.GET("/users", func(c echo.Context) error { return nil })
+
+	doc := s.doc()
+	assert.Equal(t, map[string]models.SecurityScheme{"Bearer": {Type: models.SecurityBearer}}, doc.SecuritySchemes)
+	assert.Equal(t, []generator.SecurityRequirement{{Scheme: "Bearer"}}, doc.Security)
+	assert.Equal(t, []generator.SecurityRequirement{{Scheme: "OAuth2", Scopes: []string{"admin"}}}, doc.Groups[0].Security)
+}
+
+func TestEchoSwagger_SetInfo(t *testing.T) {
+	s := NewEcho()
+	s.SetInfo(models.Info{Title: "Users API", Version: "1.0"})
+
+	assert.Equal(t, models.Info{Title: "Users API", Version: "1.0"}, s.doc().Info)
+}
+
+func TestEchoRoute_Operation(t *testing.T) {
+	sunset := time.Date(2027, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	r := &echoRoute{}
+	got := r.OperationID("listUsers").Deprecated("Use /v2/users", sunset).Extension("x-internal", true)
+	assert.NotNil(t, got)
+
+	assert.Equal(t, "listUsers", r.Route.OperationID)
+	assert.True(t, r.Route.Deprecated)
+	assert.Equal(t, "Use /v2/users", r.Route.DeprecationReason)
+	assert.Equal(t, sunset, r.Route.Sunset)
+	assert.Equal(t, map[string]any{"x-internal": true}, r.Route.Extensions)
+}
+
+func TestEchoSwagger_Tag(t *testing.T) {
+	s := NewEcho()
+	namer := func(prefixes []string) string { return "tag" }
+	s.SetTagNamer(namer)
+	s.Tag("Health", "", models.ExternalDocs{})
+
+	s.Group("/users").Tag("Users", "Manage the users", models.ExternalDocs{URL: "https://example.com"})
+
+	doc := s.doc()
+	assert.NotNil(t, doc.TagNamer)
+	assert.Equal(t, &models.Tag{Name: "Health"}, doc.Tag)
+	assert.Equal(t, &models.Tag{Name: "Users", Description: "Manage the users", ExternalDocs: models.ExternalDocs{URL: "https://example.com"}}, doc.Groups[0].Tag)
+}
+
+func TestEchoGroup_Defaults(t *testing.T) {
+	s := NewEcho(models.ReturnType{StatusCode: 500})
+	s.Produces("json").DefaultResponses(models.ReturnType{StatusCode: 401})
+
+	s.Group("/admin").
+		DefaultResponses(models.ReturnType{StatusCode: 403}).
+		HeaderParam("Authorization", "Bearer token", "string", true).
+		Accepts("mpfd")
+
+	doc := s.doc()
+	assert.Equal(t, []models.ReturnType{{StatusCode: 500}}, doc.DefaultResponses)
+	assert.Equal(t, generator.RouteDefaults{
+		Responses: []models.ReturnType{{StatusCode: 401}},
+		Produces:  []string{"json"},
+	}, doc.Defaults)
+	assert.Equal(t, generator.RouteDefaults{
+		Responses:    []models.ReturnType{{StatusCode: 403}},
+		HeaderParams: []generator.Param{{Name: "Authorization", Description: "Bearer token", ParamType: "string", Required: true}},
+		Accepts:      []string{"mpfd"},
+	}, doc.Groups[0].Defaults)
+}
+
+func TestEchoRoute_SkipDefaultResponses(t *testing.T) {
+	r := &echoRoute{}
+	got := r.SkipDefaultResponses(401).SkipDefaultResponses(403)
+	assert.NotNil(t, got)
+	assert.Equal(t, []int{401, 403}, r.Route.SkipDefaultResponses)
+	assert.False(t, r.Route.SkipAllDefaultResponses)
+
+	r.SkipDefaultResponses()
+	assert.True(t, r.Route.SkipAllDefaultResponses)
+}
+
+func TestEchoRoute_Example(t *testing.T) {
+	r := &echoRoute{}
+	got := r.Read(models.ReturnType{}).Example(models.ReturnType{StatusCode: 200}).Example(map[string]any{"StatusCode": 201})
+	assert.NotNil(t, got)
+	assert.Equal(t, []any{models.ReturnType{StatusCode: 200}, map[string]any{"StatusCode": 201}}, r.Route.ReadExamples)
+}
+
+func TestEchoRoute_ReadWithOverrides(t *testing.T) {
+	r := &echoRoute{}
+	got := r.ReadWithOverrides(models.ReturnType{}, map[string]any{"Body": models.Tag{}})
+	assert.NotNil(t, got)
+	assert.Equal(t, models.ReturnType{}, r.Route.Reads)
+	assert.Equal(t, map[string]any{"Body": models.Tag{}}, r.Route.ReadOverrides)
+}
+
+func TestEchoSwagger_Audience(t *testing.T) {
+	s := NewEcho()
+	s.Audience("public")
+	s.GET("/health", func(c echo.Context) error { return nil }).Hidden()
+	s.GET("/partners", func(c echo.Context) error { return nil }).Audience("partner", "internal")
+
+	s.Group("/admin").Audience("internal")
+	s.Group("/debug").Hidden()
+
+	doc := s.doc()
+	assert.Equal(t, []string{"public"}, doc.Audiences)
+	assert.True(t, doc.Routes[0].Hidden)
+	assert.Equal(t, []string{"partner", "internal"}, doc.Routes[1].Audiences)
+	assert.Equal(t, []string{"internal"}, doc.Groups[0].Audiences)
+	assert.True(t, doc.Groups[1].Hidden)
+}
+
+func TestEchoSwagger_Spec(t *testing.T) {
+	s := NewEcho()
+	s.SetSpecInfo("v2", models.Info{Title: "Users API", Version: "2.0"})
+	s.Group("/v1").Spec("v1")
+	s.Group("/v2").Spec("v2")
+
+	doc := s.doc()
+	assert.Equal(t, map[string]models.Info{"v2": {Title: "Users API", Version: "2.0"}}, doc.SpecInfo)
+	assert.Equal(t, "v1", doc.Groups[0].Spec)
+	assert.Equal(t, "v2", doc.Groups[1].Spec)
+}
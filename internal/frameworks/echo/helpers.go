@@ -1,10 +1,14 @@
 package echo
 
 import (
+	"strings"
+
 	"github.com/diegoclair/goswag/internal/frameworks/shared"
 	"github.com/diegoclair/goswag/internal/generator"
 )
 
+var _ shared.StaticRoute = (*echoRoute)(nil)
+
 // getFuncName returns a unique Go identifier for the handler whose fully
 // qualified name is the input string. See shared.UniqueIdentifier for the
 // rationale (collision avoidance across packages with same short name).
@@ -12,6 +16,12 @@ func getFuncName(name string) string {
 	return shared.UniqueIdentifier(name)
 }
 
+// handlerName returns the name of the handler as the manifest shows it:
+// its runtime name, without the -fm suffix of method values.
+func handlerName(name string) string {
+	return strings.TrimSuffix(name, "-fm")
+}
+
 // toGoSwagRoute converts a slice of echoRoute to a slice of generator.Route.
 // It iterates over each echoRoute in the input slice and appends its Route field to the output slice.
 // Returns the converted slice of generator.Route.
@@ -33,8 +43,14 @@ func toGoSwagGroup(from []*echoGroup) []generator.Group {
 		groups = append(groups, generator.Group{
 			GroupName: g.groupName,
 			Routes:    toGoSwagRoute(g.routes),
-			Groups:    toGoSwagGroup(g.groups)},
-		)
+			Groups:    toGoSwagGroup(g.groups),
+			Security:  g.security,
+			Tag:       g.tag,
+			Defaults:  g.defaults,
+			Hidden:    g.hidden,
+			Audiences: g.audiences,
+			Spec:      g.spec,
+		})
 	}
 
 	return groups
@@ -1,8 +1,12 @@
 package gin
 
 import (
+	"io"
+	"log"
 	"net/http"
+	"time"
 
+	"github.com/diegoclair/goswag/internal/frameworks/shared"
 	"github.com/diegoclair/goswag/internal/generator"
 	"github.com/diegoclair/goswag/models"
 	"github.com/gin-gonic/gin"
@@ -13,6 +17,16 @@ type ginSwagger struct {
 	groups           []*ginGroup
 	routes           []*ginRoute
 	defaultResponses []models.ReturnType
+	security         []generator.SecurityRequirement
+	securitySchemes  map[string]models.SecurityScheme
+	info             models.Info
+	tag              *models.Tag
+	tagNamer         models.TagNamer
+	defaults         generator.RouteDefaults
+	hidden           bool
+	audiences        []string
+	spec             string
+	specInfo         map[string]models.Info
 }
 
 func NewGin(g *gin.Engine, defaultResponses ...models.ReturnType) *ginSwagger {
@@ -26,8 +40,123 @@ func (s *ginSwagger) Gin() *gin.Engine {
 	return s.g
 }
 
+// GenerateSwagger keeps the original behaviour of exiting the process when
+// the stub can't be written. Use GenerateSwaggerWith to handle the error.
 func (s *ginSwagger) GenerateSwagger() {
-	generator.GenerateSwagger(toGoSwagRoute(s.routes), toGoSwagGroup(s.groups), s.defaultResponses)
+	if err := s.GenerateSwaggerWith(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func (s *ginSwagger) GenerateSwaggerWith(opts ...generator.Option) error {
+	return generator.Generate(s.doc(), opts...)
+}
+
+func (s *ginSwagger) Validate() []generator.Finding {
+	return generator.Validate(s.doc())
+}
+
+func (s *ginSwagger) WriteTo(w io.Writer) (int64, error) {
+	return generator.Write(w, s.doc())
+}
+
+func (s *ginSwagger) GenerateOpenAPI(dir string) error {
+	return generator.GenerateSpecsOpenAPI(s.doc(), dir)
+}
+
+func (s *ginSwagger) doc() generator.Doc {
+	return generator.Doc{
+		Routes:           toGoSwagRoute(s.routes),
+		Groups:           toGoSwagGroup(s.groups),
+		DefaultResponses: s.defaultResponses,
+		Security:         s.security,
+		SecuritySchemes:  s.securitySchemes,
+		Info:             s.info,
+		Tag:              s.tag,
+		TagNamer:         s.tagNamer,
+		Defaults:         s.defaults,
+		Hidden:           s.hidden,
+		Audiences:        s.audiences,
+		Spec:             s.spec,
+		SpecInfo:         s.specInfo,
+	}
+}
+
+func (s *ginSwagger) SetInfo(info models.Info) {
+	s.info = info
+}
+
+func (s *ginSwagger) SetSpecInfo(spec string, info models.Info) {
+	if s.specInfo == nil {
+		s.specInfo = make(map[string]models.Info)
+	}
+
+	s.specInfo[spec] = info
+}
+
+func (s *ginSwagger) SetTagNamer(namer models.TagNamer) {
+	s.tagNamer = namer
+}
+
+func (s *ginSwagger) DefaultResponses(responses ...models.ReturnType) models.GinRouter {
+	s.defaults.Responses = append(s.defaults.Responses, responses...)
+	return s
+}
+
+func (s *ginSwagger) HeaderParam(name, description, paramType string, required bool, opts ...models.ParamOption) models.GinRouter {
+	s.defaults.HeaderParams = append(s.defaults.HeaderParams, generator.Param{
+		Name:        name,
+		Description: description,
+		ParamType:   paramType,
+		Required:    required,
+
+		ParamAttributes: models.NewParamAttributes(opts...),
+	})
+
+	return s
+}
+
+func (s *ginSwagger) Accepts(accepts ...string) models.GinRouter {
+	s.defaults.Accepts = accepts
+	return s
+}
+
+func (s *ginSwagger) Produces(produces ...string) models.GinRouter {
+	s.defaults.Produces = produces
+	return s
+}
+
+func (s *ginSwagger) Tag(name, description string, externalDocs models.ExternalDocs) models.GinRouter {
+	s.tag = &models.Tag{Name: name, Description: description, ExternalDocs: externalDocs}
+	return s
+}
+
+func (s *ginSwagger) AddSecurityScheme(name string, scheme models.SecurityScheme) {
+	if s.securitySchemes == nil {
+		s.securitySchemes = make(map[string]models.SecurityScheme)
+	}
+
+	s.securitySchemes[name] = scheme
+}
+
+func (s *ginSwagger) Security(scheme string, scopes ...string) models.GinRouter {
+	s.security = append(s.security, generator.SecurityRequirement{Scheme: scheme, Scopes: scopes})
+	return s
+}
+
+func (s *ginSwagger) Hidden() models.GinRouter {
+	s.hidden = true
+	return s
+}
+
+func (s *ginSwagger) Audience(names ...string) models.GinRouter {
+	s.audiences = append(s.audiences, names...)
+	return s
+}
+
+func (s *ginSwagger) Spec(name string) models.GinRouter {
+	s.spec = name
+	return s
 }
 
 func (s *ginSwagger) Group(relativePath string, handlers ...gin.HandlerFunc) models.GinRouter {
@@ -45,6 +174,8 @@ func (s *ginSwagger) Handle(httpMethod, relativePath string, handlers ...gin.Han
 			Path:     relativePath,
 			Method:   httpMethod,
 			FuncName: getFuncName(handlers...),
+			Handler:  handlerName(handlers...),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -61,6 +192,8 @@ func (s *ginSwagger) POST(relativePath string, handlers ...gin.HandlerFunc) mode
 			Path:     relativePath,
 			Method:   http.MethodPost,
 			FuncName: getFuncName(handlers...),
+			Handler:  handlerName(handlers...),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -77,6 +210,8 @@ func (s *ginSwagger) GET(relativePath string, handlers ...gin.HandlerFunc) model
 			Path:     relativePath,
 			Method:   http.MethodGet,
 			FuncName: getFuncName(handlers...),
+			Handler:  handlerName(handlers...),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -93,6 +228,8 @@ func (s *ginSwagger) PUT(relativePath string, handlers ...gin.HandlerFunc) model
 			Path:     relativePath,
 			Method:   http.MethodPut,
 			FuncName: getFuncName(handlers...),
+			Handler:  handlerName(handlers...),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -109,6 +246,8 @@ func (s *ginSwagger) DELETE(relativePath string, handlers ...gin.HandlerFunc) mo
 			Path:     relativePath,
 			Method:   http.MethodDelete,
 			FuncName: getFuncName(handlers...),
+			Handler:  handlerName(handlers...),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -125,6 +264,8 @@ func (s *ginSwagger) PATCH(relativePath string, handlers ...gin.HandlerFunc) mod
 			Path:     relativePath,
 			Method:   http.MethodPatch,
 			FuncName: getFuncName(handlers...),
+			Handler:  handlerName(handlers...),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -141,6 +282,8 @@ func (s *ginSwagger) OPTIONS(relativePath string, handlers ...gin.HandlerFunc) m
 			Path:     relativePath,
 			Method:   http.MethodOptions,
 			FuncName: getFuncName(handlers...),
+			Handler:  handlerName(handlers...),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -157,6 +300,8 @@ func (s *ginSwagger) HEAD(relativePath string, handlers ...gin.HandlerFunc) mode
 			Path:     relativePath,
 			Method:   http.MethodHead,
 			FuncName: getFuncName(handlers...),
+			Handler:  handlerName(handlers...),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -169,6 +314,12 @@ type ginGroup struct {
 	gg        *gin.RouterGroup
 	groupName string
 	routes    []*ginRoute
+	security  []generator.SecurityRequirement
+	tag       *models.Tag
+	defaults  generator.RouteDefaults
+	hidden    bool
+	audiences []string
+	spec      string
 }
 
 func (g *ginGroup) Handle(httpMethod, relativePath string, handlers ...gin.HandlerFunc) models.Swagger {
@@ -180,6 +331,8 @@ func (g *ginGroup) Handle(httpMethod, relativePath string, handlers ...gin.Handl
 			Path:     fullPath,
 			Method:   httpMethod,
 			FuncName: getFuncName(handlers...),
+			Handler:  handlerName(handlers...),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -197,6 +350,8 @@ func (g *ginGroup) POST(relativePath string, handlers ...gin.HandlerFunc) models
 			Path:     fullPath,
 			Method:   http.MethodPost,
 			FuncName: getFuncName(handlers...),
+			Handler:  handlerName(handlers...),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -214,6 +369,8 @@ func (g *ginGroup) GET(relativePath string, handlers ...gin.HandlerFunc) models.
 			Path:     fullPath,
 			Method:   http.MethodGet,
 			FuncName: getFuncName(handlers...),
+			Handler:  handlerName(handlers...),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -231,6 +388,8 @@ func (g *ginGroup) PUT(relativePath string, handlers ...gin.HandlerFunc) models.
 			Path:     fullPath,
 			Method:   http.MethodPut,
 			FuncName: getFuncName(handlers...),
+			Handler:  handlerName(handlers...),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -248,6 +407,8 @@ func (g *ginGroup) DELETE(relativePath string, handlers ...gin.HandlerFunc) mode
 			Path:     fullPath,
 			Method:   http.MethodDelete,
 			FuncName: getFuncName(handlers...),
+			Handler:  handlerName(handlers...),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -265,6 +426,8 @@ func (g *ginGroup) PATCH(relativePath string, handlers ...gin.HandlerFunc) model
 			Path:     fullPath,
 			Method:   http.MethodPatch,
 			FuncName: getFuncName(handlers...),
+			Handler:  handlerName(handlers...),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -282,6 +445,8 @@ func (g *ginGroup) OPTIONS(relativePath string, handlers ...gin.HandlerFunc) mod
 			Path:     fullPath,
 			Method:   http.MethodOptions,
 			FuncName: getFuncName(handlers...),
+			Handler:  handlerName(handlers...),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -299,6 +464,8 @@ func (g *ginGroup) HEAD(relativePath string, handlers ...gin.HandlerFunc) models
 			Path:     fullPath,
 			Method:   http.MethodHead,
 			FuncName: getFuncName(handlers...),
+			Handler:  handlerName(handlers...),
+			Source:   shared.CallerSource(),
 		},
 	}
 
@@ -307,6 +474,59 @@ func (g *ginGroup) HEAD(relativePath string, handlers ...gin.HandlerFunc) models
 	return gr
 }
 
+func (g *ginGroup) DefaultResponses(responses ...models.ReturnType) models.GinRouter {
+	g.defaults.Responses = append(g.defaults.Responses, responses...)
+	return g
+}
+
+func (g *ginGroup) HeaderParam(name, description, paramType string, required bool, opts ...models.ParamOption) models.GinRouter {
+	g.defaults.HeaderParams = append(g.defaults.HeaderParams, generator.Param{
+		Name:        name,
+		Description: description,
+		ParamType:   paramType,
+		Required:    required,
+
+		ParamAttributes: models.NewParamAttributes(opts...),
+	})
+
+	return g
+}
+
+func (g *ginGroup) Accepts(accepts ...string) models.GinRouter {
+	g.defaults.Accepts = accepts
+	return g
+}
+
+func (g *ginGroup) Produces(produces ...string) models.GinRouter {
+	g.defaults.Produces = produces
+	return g
+}
+
+func (g *ginGroup) Tag(name, description string, externalDocs models.ExternalDocs) models.GinRouter {
+	g.tag = &models.Tag{Name: name, Description: description, ExternalDocs: externalDocs}
+	return g
+}
+
+func (g *ginGroup) Security(scheme string, scopes ...string) models.GinRouter {
+	g.security = append(g.security, generator.SecurityRequirement{Scheme: scheme, Scopes: scopes})
+	return g
+}
+
+func (g *ginGroup) Hidden() models.GinRouter {
+	g.hidden = true
+	return g
+}
+
+func (g *ginGroup) Audience(names ...string) models.GinRouter {
+	g.audiences = append(g.audiences, names...)
+	return g
+}
+
+func (g *ginGroup) Spec(name string) models.GinRouter {
+	g.spec = name
+	return g
+}
+
 type ginRoute struct {
 	Route generator.Route
 }
@@ -341,40 +561,152 @@ func (r *ginRoute) Read(reads any) models.Swagger {
 	return r
 }
 
+func (r *ginRoute) ReadWithOverrides(reads any, overrides map[string]any) models.Swagger {
+	r.Route.Reads = reads
+	r.Route.ReadOverrides = overrides
+	return r
+}
+
 func (r *ginRoute) Returns(returns []models.ReturnType) models.Swagger {
 	r.Route.Returns = returns
 	return r
 }
 
-func (r *ginRoute) QueryParam(name, description, paramType string, required bool) models.Swagger {
+func (r *ginRoute) QueryParam(name, description, paramType string, required bool, opts ...models.ParamOption) models.Swagger {
 	r.Route.QueryParams = append(r.Route.QueryParams, generator.Param{
 		Name:        name,
 		Description: description,
 		ParamType:   paramType,
 		Required:    required,
+
+		ParamAttributes: models.NewParamAttributes(opts...),
 	})
 
 	return r
 }
 
-func (r *ginRoute) HeaderParam(name, description, paramType string, required bool) models.Swagger {
+func (r *ginRoute) HeaderParam(name, description, paramType string, required bool, opts ...models.ParamOption) models.Swagger {
 	r.Route.HeaderParams = append(r.Route.HeaderParams, generator.Param{
 		Name:        name,
 		Description: description,
 		ParamType:   paramType,
 		Required:    required,
+
+		ParamAttributes: models.NewParamAttributes(opts...),
 	})
 
 	return r
 }
 
-func (r *ginRoute) PathParam(name, description, paramType string, required bool) models.Swagger {
+func (r *ginRoute) PathParam(name, description, paramType string, required bool, opts ...models.ParamOption) models.Swagger {
 	r.Route.PathParams = append(r.Route.PathParams, generator.Param{
 		Name:        name,
 		Description: description,
 		ParamType:   paramType,
 		Required:    required,
+
+		ParamAttributes: models.NewParamAttributes(opts...),
+	})
+
+	return r
+}
+
+func (r *ginRoute) FormParam(name, description, paramType string, required bool, opts ...models.ParamOption) models.Swagger {
+	r.Route.FormParams = append(r.Route.FormParams, generator.Param{
+		Name:        name,
+		Description: description,
+		ParamType:   paramType,
+		Required:    required,
+
+		ParamAttributes: models.NewParamAttributes(opts...),
 	})
 
 	return r
 }
+
+func (r *ginRoute) FileParam(name, description string, required bool) models.Swagger {
+	return r.FormParam(name, description, generator.FileParamType, required)
+}
+
+func (r *ginRoute) ReadForm(data any) models.Swagger {
+	r.Route.FormParams = append(r.Route.FormParams, generator.FormParams(data)...)
+	return r
+}
+
+func (r *ginRoute) Params(data any) models.Swagger {
+	params := generator.ParamsOf(data, r.Route.Method)
+
+	r.Route.PathParams = append(r.Route.PathParams, params.Path...)
+	r.Route.QueryParams = append(r.Route.QueryParams, params.Query...)
+	r.Route.HeaderParams = append(r.Route.HeaderParams, params.Header...)
+	r.Route.FormParams = append(r.Route.FormParams, params.Form...)
+
+	return r
+}
+
+func (r *ginRoute) Security(scheme string, scopes ...string) models.Swagger {
+	r.Route.Security = append(r.Route.Security, generator.SecurityRequirement{Scheme: scheme, Scopes: scopes})
+	return r
+}
+
+func (r *ginRoute) Public() models.Swagger {
+	r.Route.Public = true
+	return r
+}
+
+func (r *ginRoute) OperationID(id string) models.Swagger {
+	r.Route.OperationID = id
+	return r
+}
+
+func (r *ginRoute) Deprecated(reason string, sunset time.Time) models.Swagger {
+	r.Route.Deprecated = true
+	r.Route.DeprecationReason = reason
+	r.Route.Sunset = sunset
+	return r
+}
+
+func (r *ginRoute) Example(v any) models.Swagger {
+	r.Route.ReadExamples = append(r.Route.ReadExamples, v)
+	return r
+}
+
+func (r *ginRoute) Extension(key string, value any) models.Swagger {
+	if r.Route.Extensions == nil {
+		r.Route.Extensions = make(map[string]any)
+	}
+
+	r.Route.Extensions[key] = value
+	return r
+}
+
+func (r *ginRoute) Hidden() models.Swagger {
+	r.Route.Hidden = true
+	return r
+}
+
+func (r *ginRoute) Audience(names ...string) models.Swagger {
+	r.Route.Audiences = append(r.Route.Audiences, names...)
+	return r
+}
+
+// SetHandler implements shared.StaticRoute.
+func (r *ginRoute) SetHandler(funcName, handler string) {
+	r.Route.FuncName = funcName
+	r.Route.Handler = handler
+}
+
+// SetSource implements shared.StaticRoute.
+func (r *ginRoute) SetSource(source string) {
+	r.Route.Source = source
+}
+
+func (r *ginRoute) SkipDefaultResponses(codes ...int) models.Swagger {
+	if len(codes) == 0 {
+		r.Route.SkipAllDefaultResponses = true
+		return r
+	}
+
+	r.Route.SkipDefaultResponses = append(r.Route.SkipDefaultResponses, codes...)
+	return r
+}
@@ -1,8 +1,13 @@
 package gin
 
 import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/diegoclair/goswag/internal/generator"
 	"github.com/diegoclair/goswag/models"
@@ -14,12 +19,20 @@ import (
 // short name so tests can assert against a stable literal. getFuncName
 // always appends "_<hash>" to disambiguate identically-named handlers
 // across packages; we still validate the prefix matches what the test
-// expects.
+// expects. The handler and the source, which depend on where the test
+// runs from, are checked against the handler and the test file and cleared.
 func normalizeFuncName(t *testing.T, want generator.Route, got generator.Route) generator.Route {
 	t.Helper()
 	if want.FuncName == "" {
 		return got
 	}
+	if !strings.HasSuffix(got.Handler, "."+want.FuncName) {
+		t.Errorf("Handler = %q; want suffix %q", got.Handler, "."+want.FuncName)
+	}
+	if !strings.Contains(got.Source, "gin_test.go:") {
+		t.Errorf("Source = %q; want the test file", got.Source)
+	}
+	got.Handler, got.Source = "", ""
 	prefix := want.FuncName + "_"
 	if !strings.HasPrefix(got.FuncName, prefix) {
 		t.Errorf("FuncName = %q; want prefix %q", got.FuncName, prefix)
@@ -48,6 +61,39 @@ func TestGinSwagger_Gin(t *testing.T) {
 	})
 }
 
+func TestGinSwagger_GenerateSwaggerWith(t *testing.T) {
+	t.Run("should write the stub in the given directory and return no error", func(t *testing.T) {
+		dir := t.TempDir()
+		s := NewGin(gin.New())
+		s.GET("/test", func(c *gin.Context) {}).Summary("test")
+
+		err := s.GenerateSwaggerWith(
+			generator.WithOutputDir(dir),
+			generator.WithFileName("docs.go"),
+			generator.WithLogger(log.New(io.Discard, "", 0)),
+		)
+		assert.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(dir, "docs.go"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "package main\n")
+		assert.Contains(t, string(content), "// @Router /test [get]\n")
+	})
+}
+
+func TestGinSwagger_WriteTo(t *testing.T) {
+	t.Run("should write the stub content to the writer", func(t *testing.T) {
+		s := NewGin(gin.New())
+		s.GET("/test", func(c *gin.Context) {}).Summary("test")
+
+		var b strings.Builder
+		n, err := s.WriteTo(&b)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(b.Len()), n)
+		assert.Contains(t, b.String(), "// @Summary test\n")
+	})
+}
+
 func TestGinSwagger_Group(t *testing.T) {
 	t.Run("should return gin group", func(t *testing.T) {
 		g := gin.Default()
@@ -723,3 +769,223 @@ func TestGinRoute_PathParam(t *testing.T) {
 		assert.Equal(t, []generator.Param{{Name: "test", Description: "test", ParamType: "test", Required: true}}, g.Route.PathParams)
 	})
 }
+
+func TestGinRoute_FormParam(t *testing.T) {
+	t.Run("should add form param", func(t *testing.T) {
+		g := &ginRoute{}
+		got := g.FormParam("test", "test", "test", true)
+		assert.NotNil(t, got)
+		assert.Equal(t, []generator.Param{{Name: "test", Description: "test", ParamType: "test", Required: true}}, g.Route.FormParams)
+	})
+}
+
+func TestGinRoute_FileParam(t *testing.T) {
+	t.Run("should add file param", func(t *testing.T) {
+		g := &ginRoute{}
+		got := g.FileParam("test", "test", true)
+		assert.NotNil(t, got)
+		assert.Equal(t, []generator.Param{{Name: "test", Description: "test", ParamType: generator.FileParamType, Required: true}}, g.Route.FormParams)
+	})
+}
+
+func TestGinRoute_ReadForm(t *testing.T) {
+	t.Run("should add the form params of the struct", func(t *testing.T) {
+		g := &ginRoute{}
+		got := g.ReadForm(struct {
+			Name string `form:"name" binding:"required"`
+		}{})
+		assert.NotNil(t, got)
+		assert.Equal(t, []generator.Param{{Name: "name", ParamType: "string", Required: true}}, g.Route.FormParams)
+	})
+}
+
+func TestGinRoute_PathParam_options(t *testing.T) {
+	t.Run("should add the param attributes", func(t *testing.T) {
+		g := &ginRoute{}
+		g.PathParam("id", "id", "string", true, models.Format("uuid"), models.MaxLength(36))
+
+		maxLength := 36
+		assert.Equal(t, []generator.Param{
+			{
+				Name: "id", Description: "id", ParamType: "string", Required: true,
+				ParamAttributes: models.ParamAttributes{Format: "uuid", MaxLength: &maxLength},
+			},
+		}, g.Route.PathParams)
+	})
+}
+
+func TestGinRoute_Params(t *testing.T) {
+	t.Run("should add the params of the struct", func(t *testing.T) {
+		g := &ginRoute{Route: generator.Route{Method: "PUT"}}
+		got := g.Params(struct {
+			ID   string `uri:"id"`
+			Name string `form:"name" binding:"required"`
+		}{})
+		assert.NotNil(t, got)
+		assert.Equal(t, []generator.Param{{Name: "id", ParamType: "string", Required: true}}, g.Route.PathParams)
+		assert.Equal(t, []generator.Param{{Name: "name", ParamType: "string", Required: true}}, g.Route.FormParams)
+	})
+}
+
+func TestGinRoute_Security(t *testing.T) {
+	t.Run("should add the security requirements", func(t *testing.T) {
+		g := &ginRoute{}
+		got := g.Security("OAuth2", "read").Security("ApiKey")
+		assert.NotNil(t, got)
+		assert.Equal(t, []generator.SecurityRequirement{
+			{Scheme: "OAuth2", Scopes: []string{"read"}},
+			{Scheme: "ApiKey"},
+		}, g.Route.Security)
+	})
+}
+
+func TestGinRoute_Public(t *testing.T) {
+	t.Run("should mark the route as public", func(t *testing.T) {
+		g := &ginRoute{}
+		got := g.Public()
+		assert.NotNil(t, got)
+		assert.True(t, g.Route.Public)
+	})
+}
+
+func TestGinSwagger_Security(t *testing.T) {
+	t.Run("should carry the schemes and the instance and group security to the doc", func(t *testing.T) {
+		s := NewGin(gin.New())
+		s.AddSecurityScheme("Bearer", models.SecurityScheme{Type: models.SecurityBearer})
+		s.Security("Bearer")
+
+		g := s.Group("/admin").Security("OAuth2", "admin")
+		g.GET("/users", func(c *gin.Context) {})
+
+		doc := s.doc()
+		assert.Equal(t, map[string]models.SecurityScheme{"Bearer": {Type: models.SecurityBearer}}, doc.SecuritySchemes)
+		assert.Equal(t, []generator.SecurityRequirement{{Scheme: "Bearer"}}, doc.Security)
+		assert.Equal(t, []generator.SecurityRequirement{{Scheme: "OAuth2", Scopes: []string{"admin"}}}, doc.Groups[0].Security)
+	})
+}
+
+func TestGinSwagger_SetInfo(t *testing.T) {
+	t.Run("should carry the info to the doc", func(t *testing.T) {
+		s := NewGin(gin.New())
+		s.SetInfo(models.Info{Title: "Users API", Version: "1.0"})
+
+		assert.Equal(t, models.Info{Title: "Users API", Version: "1.0"}, s.doc().Info)
+	})
+}
+
+func TestGinRoute_Operation(t *testing.T) {
+	t.Run("should set the operation id, the deprecation and the extensions", func(t *testing.T) {
+		sunset := time.Date(2027, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+		g := &ginRoute{}
+		got := g.OperationID("listUsers").Deprecated("", sunset).Extension("internal", true)
+		assert.NotNil(t, got)
+		assert.Equal(t, "listUsers", g.Route.OperationID)
+		assert.True(t, g.Route.Deprecated)
+		assert.Equal(t, sunset, g.Route.Sunset)
+		assert.Equal(t, map[string]any{"internal": true}, g.Route.Extensions)
+	})
+}
+
+func TestGinSwagger_Tag(t *testing.T) {
+	t.Run("should carry the declared tags and the namer to the doc", func(t *testing.T) {
+		s := NewGin(gin.New())
+		s.SetTagNamer(func(prefixes []string) string { return "tag" })
+		s.Tag("Health", "", models.ExternalDocs{})
+
+		s.Group("/users").Tag("Users", "Manage the users", models.ExternalDocs{})
+
+		doc := s.doc()
+		assert.NotNil(t, doc.TagNamer)
+		assert.Equal(t, &models.Tag{Name: "Health"}, doc.Tag)
+		assert.Equal(t, &models.Tag{Name: "Users", Description: "Manage the users"}, doc.Groups[0].Tag)
+	})
+}
+
+func TestGinGroup_Defaults(t *testing.T) {
+	t.Run("should carry the instance and group defaults to the doc", func(t *testing.T) {
+		s := NewGin(gin.New())
+		s.Accepts("json").HeaderParam("X-Request-ID", "", "string", false)
+
+		s.Group("/admin").
+			DefaultResponses(models.ReturnType{StatusCode: 403}).
+			Produces("xml")
+
+		doc := s.doc()
+		assert.Equal(t, generator.RouteDefaults{
+			HeaderParams: []generator.Param{{Name: "X-Request-ID", ParamType: "string"}},
+			Accepts:      []string{"json"},
+		}, doc.Defaults)
+		assert.Equal(t, generator.RouteDefaults{
+			Responses: []models.ReturnType{{StatusCode: 403}},
+			Produces:  []string{"xml"},
+		}, doc.Groups[0].Defaults)
+	})
+}
+
+func TestGinRoute_SkipDefaultResponses(t *testing.T) {
+	t.Run("should skip the given codes", func(t *testing.T) {
+		g := &ginRoute{}
+		got := g.SkipDefaultResponses(401)
+		assert.NotNil(t, got)
+		assert.Equal(t, []int{401}, g.Route.SkipDefaultResponses)
+	})
+
+	t.Run("should skip all the default responses without codes", func(t *testing.T) {
+		g := &ginRoute{}
+		g.SkipDefaultResponses()
+		assert.True(t, g.Route.SkipAllDefaultResponses)
+	})
+}
+
+func TestGinRoute_Example(t *testing.T) {
+	t.Run("should add the examples of the request body", func(t *testing.T) {
+		g := &ginRoute{}
+		got := g.Read(models.ReturnType{}).Example(models.ReturnType{StatusCode: 200}).Example(map[string]any{"StatusCode": 201})
+		assert.NotNil(t, got)
+		assert.Equal(t, []any{models.ReturnType{StatusCode: 200}, map[string]any{"StatusCode": 201}}, g.Route.ReadExamples)
+	})
+}
+
+func TestGinRoute_ReadWithOverrides(t *testing.T) {
+	t.Run("should set the request body and its overrides", func(t *testing.T) {
+		g := &ginRoute{}
+		got := g.ReadWithOverrides(models.ReturnType{}, map[string]any{"Body": models.Tag{}})
+		assert.NotNil(t, got)
+		assert.Equal(t, models.ReturnType{}, g.Route.Reads)
+		assert.Equal(t, map[string]any{"Body": models.Tag{}}, g.Route.ReadOverrides)
+	})
+}
+
+func TestGinSwagger_Audience(t *testing.T) {
+	t.Run("should carry the hidden routes and the audiences to the doc", func(t *testing.T) {
+		s := NewGin(gin.New())
+		s.Audience("public")
+		s.GET("/health", func(c *gin.Context) {}).Hidden()
+		s.GET("/partners", func(c *gin.Context) {}).Audience("partner", "internal")
+
+		s.Group("/admin").Audience("internal")
+		s.Group("/debug").Hidden()
+
+		doc := s.doc()
+		assert.Equal(t, []string{"public"}, doc.Audiences)
+		assert.True(t, doc.Routes[0].Hidden)
+		assert.Equal(t, []string{"partner", "internal"}, doc.Routes[1].Audiences)
+		assert.Equal(t, []string{"internal"}, doc.Groups[0].Audiences)
+		assert.True(t, doc.Groups[1].Hidden)
+	})
+}
+
+func TestGinSwagger_Spec(t *testing.T) {
+	t.Run("should carry the specs and their info to the doc", func(t *testing.T) {
+		s := NewGin(gin.New())
+		s.SetSpecInfo("v2", models.Info{Title: "Users API", Version: "2.0"})
+		s.Group("/v1").Spec("v1")
+		s.Group("/v2").Spec("v2")
+
+		doc := s.doc()
+		assert.Equal(t, map[string]models.Info{"v2": {Title: "Users API", Version: "2.0"}}, doc.SpecInfo)
+		assert.Equal(t, "v1", doc.Groups[0].Spec)
+		assert.Equal(t, "v2", doc.Groups[1].Spec)
+	})
+}
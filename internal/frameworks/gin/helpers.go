@@ -11,14 +11,26 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+var _ shared.StaticRoute = (*ginRoute)(nil)
+
 // getFuncName resolves the last handler in the chain to a unique Go
 // identifier. The last handler is the one that defines the route (earlier
 // entries are middlewares). See shared.UniqueIdentifier for the rationale
 // behind the disambiguation suffix.
 func getFuncName(handlers ...gin.HandlerFunc) string {
+	return shared.UniqueIdentifier(runtimeName(handlers...))
+}
+
+// handlerName returns the name of the handler as the manifest shows it:
+// the runtime name of the last handler, without the -fm suffix of method
+// values.
+func handlerName(handlers ...gin.HandlerFunc) string {
+	return strings.TrimSuffix(runtimeName(handlers...), "-fm")
+}
+
+func runtimeName(handlers ...gin.HandlerFunc) string {
 	lastHandler := handlers[len(handlers)-1]
-	fullFuncName := runtime.FuncForPC(reflect.ValueOf(lastHandler).Pointer()).Name()
-	return shared.UniqueIdentifier(fullFuncName)
+	return runtime.FuncForPC(reflect.ValueOf(lastHandler).Pointer()).Name()
 }
 
 // toGoSwagRoute converts a slice of ginRoute to a slice of generator.Route.
@@ -42,6 +54,12 @@ func toGoSwagGroup(from []*ginGroup) []generator.Group {
 		groups = append(groups, generator.Group{
 			GroupName: g.groupName,
 			Routes:    toGoSwagRoute(g.routes),
+			Security:  g.security,
+			Tag:       g.tag,
+			Defaults:  g.defaults,
+			Hidden:    g.hidden,
+			Audiences: g.audiences,
+			Spec:      g.spec,
 		})
 	}
 
@@ -5,6 +5,8 @@ package shared
 import (
 	"crypto/sha1"
 	"encoding/hex"
+	"runtime"
+	"strconv"
 	"strings"
 )
 
@@ -48,3 +50,23 @@ func UniqueIdentifier(fullName string) string {
 	h := sha1.Sum([]byte(qualifier))
 	return funcName + "_" + hex.EncodeToString(h[:4])
 }
+
+// CallerSource returns where the adapter method calling it was called from,
+// as file:line: the place a route is registered. It is "" when the runtime
+// can't tell.
+func CallerSource() string {
+	_, file, line, ok := runtime.Caller(2)
+	if !ok {
+		return ""
+	}
+
+	return file + ":" + strconv.Itoa(line)
+}
+
+// StaticRoute is implemented by the routes of every adapter. `goswag docs
+// --static` registers routes with a placeholder handler, from its own code,
+// so it sets the handler and the source found in the user code instead.
+type StaticRoute interface {
+	SetHandler(funcName, handler string)
+	SetSource(source string)
+}
@@ -84,3 +84,12 @@ func TestUniqueIdentifier_is_deterministic(t *testing.T) {
 		t.Fatalf("non-deterministic output: %q vs %q", first, second)
 	}
 }
+
+func TestCallerSource(t *testing.T) {
+	register := func() string { return CallerSource() }
+
+	got := register()
+	if !strings.Contains(got, "handlerid_test.go:") {
+		t.Errorf("CallerSource() = %q; want the line calling register in handlerid_test.go", got)
+	}
+}
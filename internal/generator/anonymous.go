@@ -0,0 +1,415 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/diegoclair/goswag/models"
+)
+
+// stubTypes are the types declared in the stub for the anonymous structs of
+// the bodies. swag resolves the types of annotations by name and an
+// anonymous struct has none, so each one gets a named type in the stub,
+// after the route it documents: CreateUserRequest, CreateUser201Response.
+// Declaring them, rather than describing them inline, keeps the struct tags
+// swag reads: required fields, descriptions, examples...
+type stubTypes struct {
+	names map[reflect.Type]string // identical structs share their declaration
+	taken map[string]bool
+	types []reflect.Type // in declaration order
+}
+
+// declareAnonymousTypes returns a copy of the routes and groups where the
+// bodies and overrides holding an anonymous struct are replaced by a
+// StaticType naming the type declared for the struct in the stub. The
+// declared types don't reuse the names of the handler funcs of the stub, nor
+// the reserved ones: the declarations of the package the stub is written to.
+func declareAnonymousTypes(routes []Route, groups []Group, reserved map[string]bool) ([]Route, []Group, *stubTypes) {
+	st := &stubTypes{names: make(map[reflect.Type]string), taken: make(map[string]bool)}
+	for name := range reserved {
+		st.taken[name] = true
+	}
+	st.takeFuncNames(routes, groups)
+
+	var declare func(routes []Route, groups []Group) ([]Route, []Group)
+	declare = func(routes []Route, groups []Group) ([]Route, []Group) {
+		var (
+			newRoutes []Route
+			newGroups []Group
+		)
+
+		for _, r := range routes {
+			newRoutes = append(newRoutes, st.declareRoute(r))
+		}
+
+		for _, g := range groups {
+			g.Routes, g.Groups = declare(g.Routes, g.Groups)
+			newGroups = append(newGroups, g)
+		}
+
+		return newRoutes, newGroups
+	}
+
+	routes, groups = declare(routes, groups)
+
+	return routes, groups, st
+}
+
+func (st *stubTypes) takeFuncNames(routes []Route, groups []Group) {
+	for _, r := range routes {
+		if r.FuncName != "" {
+			st.taken[r.FuncName] = true
+		}
+	}
+
+	for _, g := range groups {
+		st.takeFuncNames(g.Routes, g.Groups)
+	}
+}
+
+// packageDeclarations returns the names declared at the top level of the
+// files of package packageName in dir, but the stub fileName: a stub written
+// next to goswag/main.go shares package main with the files of that folder.
+// A missing dir declares nothing, and the files that don't parse are left to
+// the compiler.
+func packageDeclarations(dir, fileName, packageName string) (map[string]bool, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", dir, err)
+	}
+
+	declared := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.IsDir() || entry.Name() == fileName || filepath.Ext(entry.Name()) != ".go" {
+			continue
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, entry.Name()), nil, parser.SkipObjectResolution)
+		if err != nil || file.Name.Name != packageName {
+			continue
+		}
+
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					declared[decl.Name.Name] = true
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						declared[spec.Name.Name] = true
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							declared[name.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return declared, nil
+}
+
+func (st *stubTypes) declareRoute(r Route) Route {
+	base := stubTypeBase(r)
+
+	r.Reads = st.declareBody(r.Reads, base+"Request")
+	r.ReadOverrides = st.declareOverrides(r.ReadOverrides, base+"Request")
+
+	if r.Returns != nil {
+		returns := make([]models.ReturnType, 0, len(r.Returns))
+		for _, ret := range r.Returns {
+			name := base + exportedIdentifier(responseCode(ret)) + "Response"
+			ret.Body = st.declareBody(ret.Body, name)
+			ret.OverrideStructFields = st.declareOverrides(ret.OverrideStructFields, name)
+			returns = append(returns, ret)
+		}
+		r.Returns = returns
+	}
+
+	return r
+}
+
+// declareOverrides declares the anonymous structs of the override values,
+// after the body and the overridden field: CreateUserRequestItems.
+func (st *stubTypes) declareOverrides(overrides map[string]any, name string) map[string]any {
+	if len(overrides) == 0 {
+		return overrides
+	}
+
+	declared := make(map[string]any, len(overrides))
+	for _, key := range sortedKeys(overrides) {
+		declared[key] = st.declareBody(overrides[key], name+exportedIdentifier(key))
+	}
+
+	return declared
+}
+
+// declareBody returns body, or a StaticType for it when its type holds an
+// anonymous struct, declared as name.
+func (st *stubTypes) declareBody(body any, name string) any {
+	if isStatic(body) {
+		return body
+	}
+
+	t := reflect.TypeOf(body)
+	if anonymousStruct(t) == nil {
+		return body
+	}
+
+	var s StaticType
+	packages := make(map[string]bool)
+
+	s.Name, s.QualifiedName = st.typeNames(t, name, packages)
+	s.Kind, s.Elem, s.QualifiedElem = "object", s.Name, s.QualifiedName
+
+	if t = derefType(t); t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		s.Kind = "array"
+		s.Elem, s.QualifiedElem = st.typeNames(t.Elem(), name, packages)
+	}
+
+	for _, pkg := range sortedKeys(packages) {
+		s.Packages = append(s.Packages, pkg)
+	}
+
+	return s
+}
+
+// typeNames renders t, where the anonymous struct is the type declared as
+// name, both the way typeName does and qualified by package paths.
+func (st *stubTypes) typeNames(t reflect.Type, name string, packages map[string]bool) (short, qualified string) {
+	t = derefType(t)
+
+	switch {
+	case t.Name() == "" && t.Kind() == reflect.Struct:
+		name = st.declare(t, name)
+		return name, name
+
+	case t.Name() != "":
+		short = typeName(t, packages)
+		if t.PkgPath() == "" {
+			return short, short
+		}
+		return short, t.PkgPath() + "." + t.Name()
+
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		short, qualified = st.typeNames(t.Elem(), name, packages)
+		return "[]" + short, "[]" + qualified
+
+	case t.Kind() == reflect.Map:
+		key := typeName(t.Key(), packages)
+		short, qualified = st.typeNames(t.Elem(), name, packages)
+		return "map[" + key + "]" + short, "map[" + key + "]" + qualified
+	}
+
+	short = typeName(t, packages)
+	return short, short
+}
+
+// declare registers the declaration of the struct t, named name or, when
+// another type has it, name2, name3...
+func (st *stubTypes) declare(t reflect.Type, name string) string {
+	if declared, ok := st.names[t]; ok {
+		return declared
+	}
+
+	unique := name
+	for n := 2; st.taken[unique]; n++ {
+		unique = name + strconv.Itoa(n)
+	}
+
+	st.taken[unique] = true
+	st.names[t] = unique
+	st.types = append(st.types, t)
+
+	return unique
+}
+
+// write renders the declarations and returns them with the name each
+// package they refer to is used with, for the imports of the stub.
+func (st *stubTypes) write(packagesToImport map[string]bool) (string, map[string]string) {
+	var s strings.Builder
+	used := make(map[string]string)
+
+	for _, t := range st.types {
+		decl := "type " + st.names[t] + " " + goType(t, packagesToImport, used)
+
+		// the declarations are gofmt'ed for the stub to stay readable;
+		// goType only writes valid Go, so this does not fail
+		if formatted, err := format.Source([]byte(decl)); err == nil {
+			decl = string(formatted)
+		}
+
+		first, rest, _ := strings.Cut(decl, "\n")
+		s.WriteString(first + " //nolint:unused\n")
+		if rest != "" {
+			s.WriteString(rest + "\n")
+		}
+		s.WriteString("\n")
+	}
+
+	return s.String(), used
+}
+
+// goType writes t as Go source, with its packages qualified the way the
+// annotations are, and records the name each package is used with in used.
+// The fields that can't be encoded to JSON, unexported fields, funcs and
+// channels, are left out.
+func goType(t reflect.Type, packagesToImport map[string]bool, used map[string]string) string {
+	if t.Name() != "" {
+		if t.PkgPath() == "" || t.PkgPath() == "main" {
+			return t.Name()
+		}
+
+		name := typeName(t, packagesToImport)
+		if expr, err := parseTypeExpr(t.PkgPath() + "." + t.Name()); err == nil {
+			expr.walkPackages(func(pkgPath string) {
+				used[pkgPath] = qualifier(pkgPath, packageNameFromPath(pkgPath), packagesToImport)
+			})
+		}
+
+		// the package name, unlike the last element of its path, is only
+		// available through String()
+		pkgName, _, _ := strings.Cut(t.String(), ".")
+		used[t.PkgPath()] = qualifier(t.PkgPath(), pkgName, packagesToImport)
+
+		return name
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return "*" + goType(t.Elem(), packagesToImport, used)
+	case reflect.Slice:
+		return "[]" + goType(t.Elem(), packagesToImport, used)
+	case reflect.Array:
+		return "[" + strconv.Itoa(t.Len()) + "]" + goType(t.Elem(), packagesToImport, used)
+	case reflect.Map:
+		return "map[" + goType(t.Key(), packagesToImport, used) + "]" + goType(t.Elem(), packagesToImport, used)
+	case reflect.Struct:
+		return goStruct(t, packagesToImport, used)
+	}
+
+	return "interface{}"
+}
+
+func goStruct(t reflect.Type, packagesToImport map[string]bool, used map[string]string) string {
+	var s strings.Builder
+	s.WriteString("struct {\n")
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Type.Kind() == reflect.Func || field.Type.Kind() == reflect.Chan {
+			continue
+		}
+
+		if !field.Anonymous {
+			s.WriteString(field.Name + " ")
+		}
+		s.WriteString(goType(field.Type, packagesToImport, used))
+
+		if field.Tag != "" {
+			tag := string(field.Tag)
+			if strings.Contains(tag, "`") {
+				tag = strconv.Quote(tag)
+			} else {
+				tag = "`" + tag + "`"
+			}
+			s.WriteString(" " + tag)
+		}
+
+		s.WriteString("\n")
+	}
+
+	s.WriteString("}")
+
+	return s.String()
+}
+
+// walkPackages calls fn with the path of every package e refers to.
+func (e *typeExpr) walkPackages(fn func(pkgPath string)) {
+	if e == nil {
+		return
+	}
+
+	if e.pkgPath != "" {
+		fn(e.pkgPath)
+	}
+
+	for _, a := range e.args {
+		a.walkPackages(fn)
+	}
+	e.key.walkPackages(fn)
+	e.elem.walkPackages(fn)
+}
+
+// anonymousStruct returns the anonymous struct t holds outside of named
+// types, behind pointers, slices and map values, or nil.
+func anonymousStruct(t reflect.Type) reflect.Type {
+	for t != nil && t.Name() == "" {
+		switch t.Kind() {
+		case reflect.Struct:
+			return t
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// derefType strips the pointers of t.
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+// stubTypeBase returns what the types declared for a route are named after:
+// its operation id or, without one, its method and path: GetUsersId.
+func stubTypeBase(r Route) string {
+	base := exportedIdentifier(r.OperationID)
+	if base == "" {
+		base = exportedIdentifier(strings.ToLower(r.Method) + " " + r.Path)
+	}
+
+	if base == "" || unicode.IsDigit([]rune(base)[0]) {
+		base = "Route" + base
+	}
+
+	return base
+}
+
+// exportedIdentifier capitalizes the words s is made of and joins them:
+// "get /users/{id}" gives GetUsersId.
+func exportedIdentifier(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+	for _, w := range words {
+		runes := []rune(w)
+		b.WriteString(string(unicode.ToUpper(runes[0])) + string(runes[1:]))
+	}
+
+	return b.String()
+}
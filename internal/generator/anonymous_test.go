@@ -0,0 +1,198 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	billingdto "github.com/diegoclair/goswag/internal/generator/testutil/billing/dto"
+	"github.com/diegoclair/goswag/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type anonymousTestItem = struct {
+	ID int `json:"id"`
+}
+
+func TestWrite_anonymousTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		route    Route
+		expected []string
+	}{
+		{
+			name: "Should declare a type for an anonymous request body",
+			route: Route{
+				Method:   "POST",
+				Path:     "/users",
+				FuncName: "createUser",
+				Reads: struct {
+					Name   string `json:"name" binding:"required"`
+					secret string
+				}{},
+			},
+			expected: []string{
+				"type CreateUserRequest struct { //nolint:unused\n\tName string `json:\"name\" binding:\"required\"`\n}\n\n",
+				"// @Param request body CreateUserRequest true \"Request\"\n",
+			},
+		},
+		{
+			name: "Should declare the element of slices and maps of anonymous structs",
+			route: Route{
+				Method:   "GET",
+				Path:     "/items",
+				FuncName: "listItems",
+				Returns: []models.ReturnType{
+					{StatusCode: 200, Body: []anonymousTestItem{}},
+					{StatusCode: 206, Body: map[string]*anonymousTestItem{}},
+				},
+			},
+			expected: []string{
+				"type ListItems200Response struct { //nolint:unused\n\tID int `json:\"id\"`\n}\n\n",
+				"// @Success 200 {array} ListItems200Response\n",
+				"// @Success 206 {object} map[string]ListItems200Response\n",
+			},
+		},
+		{
+			name: "Should keep maps of any as they are",
+			route: Route{
+				Method:  "GET",
+				Path:    "/stats",
+				Returns: []models.ReturnType{{StatusCode: 200, Body: map[string]any{}}},
+			},
+			expected: []string{"// @Success 200 {object} map[string]interface{}\n"},
+		},
+		{
+			name: "Should name the types after the method and path of routes without an operation id",
+			route: Route{
+				Method:  "GET",
+				Path:    "/users/{id}",
+				Returns: []models.ReturnType{{Default: true, Body: struct{ Message string }{}}},
+			},
+			expected: []string{
+				"type GetUsersIdDefaultResponse struct { //nolint:unused\n\tMessage string\n}\n\n",
+				"// @Failure default {object} GetUsersIdDefaultResponse\n",
+			},
+		},
+		{
+			name: "Should import the packages of the fields of the declared types",
+			route: Route{
+				Method:   "PUT",
+				Path:     "/accounts",
+				FuncName: "updateAccount",
+				Reads: struct {
+					User  billingdto.User                   `json:"user"`
+					Users *billingdto.Page[billingdto.User] `json:"users"`
+				}{},
+			},
+			expected: []string{
+				"import (\n\t\"" + testutilPkg + "/billing/dto\"\n)\n\n",
+				"\tUser  dto.User            `json:\"user\"`\n\tUsers *dto.Page[dto.User] `json:\"users\"`\n",
+			},
+		},
+		{
+			name: "Should declare the anonymous structs of overrides",
+			route: Route{
+				Method:        "POST",
+				Path:          "/pages",
+				FuncName:      "createPage",
+				Reads:         billingdto.Page[billingdto.User]{},
+				ReadOverrides: map[string]any{"items": []anonymousTestItem{}},
+			},
+			expected: []string{
+				"type CreatePageRequestItems struct { //nolint:unused\n",
+				"// @Param request body dto.Page[dto.User]{items=[]CreatePageRequestItems} true \"Request\"\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+
+			_, err := Write(&b, Doc{Routes: []Route{tt.route}})
+			require.NoError(t, err)
+
+			for _, expected := range tt.expected {
+				assert.Contains(t, b.String(), expected)
+			}
+		})
+	}
+}
+
+func TestDeclareAnonymousTypes(t *testing.T) {
+	item := []models.ReturnType{{StatusCode: 200, Body: anonymousTestItem{}}}
+	other := []models.ReturnType{{StatusCode: 200, Body: struct{ Name string }{}}}
+
+	routes, _, declared := declareAnonymousTypes([]Route{
+		{Method: "GET", Path: "/a", OperationID: "getItem", Returns: item},
+		{Method: "GET", Path: "/b", OperationID: "getItem", Returns: item},
+		{Method: "GET", Path: "/c", OperationID: "getItem", Returns: other},
+	}, nil, nil)
+
+	assert.Equal(t, "GetItem200Response", routes[0].Returns[0].Body.(StaticType).Name)
+	assert.Equal(t, "GetItem200Response", routes[1].Returns[0].Body.(StaticType).Name, "identical structs share their type")
+	assert.Equal(t, "GetItem200Response2", routes[2].Returns[0].Body.(StaticType).Name)
+	assert.Len(t, declared.types, 2)
+
+	assert.Equal(t, anonymousTestItem{}, item[0].Body, "the routes given are left untouched")
+}
+
+func TestDeclareAnonymousTypes_takenNames(t *testing.T) {
+	returns := []models.ReturnType{{StatusCode: 200, Body: anonymousTestItem{}}}
+
+	routes, groups, _ := declareAnonymousTypes(
+		[]Route{{Method: "GET", Path: "/a", OperationID: "getItem", Returns: returns}},
+		[]Group{{Routes: []Route{{Method: "GET", Path: "/b", FuncName: "GetItem200Response"}}}},
+		map[string]bool{"GetItem200Response2": true},
+	)
+
+	assert.Equal(t, "GetItem200Response3", routes[0].Returns[0].Body.(StaticType).Name, "neither a handler func nor a declaration of the package")
+	assert.Equal(t, "GetItem200Response", groups[0].Routes[0].FuncName)
+}
+
+func TestPackageDeclarations(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.go":     "package main\n\ntype CreateUserRequest struct{}\n\nvar listUsers, port = 1, 2\n\nconst version = \"1\"\n\nfunc main() {}\n\nfunc (CreateUserRequest) Validate() {}\n",
+		"goswag.go":   "package main\n\ntype GetItem200Response struct{}\n",
+		"other.go":    "package other\n\ntype Other struct{}\n",
+		"broken.go":   "package main\n\ntype",
+		"notes.txt":   "type Notes struct{}",
+		"handlers.go": "package main\n\ntype (\n\tA int\n\tB int\n)\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	declared, err := packageDeclarations(dir, "goswag.go", "main")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		"CreateUserRequest": true, "listUsers": true, "port": true, "version": true, "main": true, "A": true, "B": true,
+	}, declared)
+
+	declared, err = packageDeclarations(filepath.Join(dir, "missing"), "goswag.go", "main")
+	require.NoError(t, err)
+	assert.Empty(t, declared)
+}
+
+func TestExportedIdentifier(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "createUser", expected: "CreateUser"},
+		{input: "get /users/{id}", expected: "GetUsersId"},
+		{input: "list-orders_2", expected: "ListOrders2"},
+		{input: "default", expected: "Default"},
+		{input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run("Should return "+tt.expected+" for "+tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, exportedIdentifier(tt.input))
+		})
+	}
+}
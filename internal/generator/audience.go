@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AudienceEnv is the environment variable `goswag docs --audience` sets to
+// make GenerateSwagger document the routes of that audience only or, with a
+// comma-separated list, write a stub per audience.
+const AudienceEnv = "GOSWAG_AUDIENCE"
+
+// SplitAudiences returns the audiences of a comma-separated list, e.g.
+// "public,internal", or nil when list names a single audience.
+func SplitAudiences(list string) []string {
+	if !strings.Contains(list, ",") {
+		return nil
+	}
+
+	var audiences []string
+	for _, audience := range strings.Split(list, ",") {
+		if audience = strings.TrimSpace(audience); audience != "" && !slices.Contains(audiences, audience) {
+			audiences = append(audiences, audience)
+		}
+	}
+
+	return audiences
+}
+
+// checkAudiences returns an error when one of audiences can't name the
+// folder of its stub, or names the folder of a spec of doc.
+func checkAudiences(audiences []string, doc Doc) error {
+	specs := make(map[string]bool)
+	for _, spec := range Specs(doc) {
+		specs[spec.Name] = true
+	}
+
+	for _, audience := range audiences {
+		if audience == "" || audience == "." || audience == ".." || strings.ContainsAny(audience, `/\`) {
+			return fmt.Errorf("audience %q can't name the folder of its stub", audience)
+		}
+
+		if specs[audience] {
+			return fmt.Errorf("audience %q is also the name of a spec, their stubs would share a folder", audience)
+		}
+	}
+
+	return nil
+}
+
+// documentedRoutes returns the routes and groups of doc documented for
+// audience: the hidden routes are dropped and, when audience is set, so are
+// the routes meant for other audiences. A route is meant for the audiences
+// it declares, or else the ones of the closest group that declares some, or
+// else the instance ones. Routes without audiences are meant for all of them.
+func documentedRoutes(doc Doc, audience string) ([]Route, []Group) {
+	return selectRoutes(doc.Routes, doc.Groups, audience, doc.Audiences, doc.Hidden)
+}
+
+func selectRoutes(routes []Route, groups []Group, audience string, inherited []string, hidden bool) ([]Route, []Group) {
+	var (
+		newRoutes []Route
+		newGroups []Group
+	)
+
+	for _, r := range routes {
+		audiences := inherited
+		if len(r.Audiences) > 0 {
+			audiences = r.Audiences
+		}
+
+		if hidden || r.Hidden || !meantFor(audiences, audience) {
+			continue
+		}
+		newRoutes = append(newRoutes, r)
+	}
+
+	for _, g := range groups {
+		audiences := inherited
+		if len(g.Audiences) > 0 {
+			audiences = g.Audiences
+		}
+
+		hadRoutes := len(g.Routes) > 0 || len(g.Groups) > 0
+		g.Routes, g.Groups = selectRoutes(g.Routes, g.Groups, audience, audiences, hidden || g.Hidden)
+
+		// a group left without routes would still declare its tag
+		if hadRoutes && len(g.Routes) == 0 && len(g.Groups) == 0 {
+			continue
+		}
+		newGroups = append(newGroups, g)
+	}
+
+	return newRoutes, newGroups
+}
+
+func meantFor(audiences []string, audience string) bool {
+	return audience == "" || len(audiences) == 0 || slices.Contains(audiences, audience)
+}
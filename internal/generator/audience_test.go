@@ -0,0 +1,206 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentedRoutes(t *testing.T) {
+	doc := Doc{
+		Routes: []Route{
+			{Method: "GET", Path: "/users"},
+			{Method: "GET", Path: "/health", Hidden: true},
+			{Method: "GET", Path: "/partners", Audiences: []string{"partner", "internal"}},
+		},
+		Groups: []Group{
+			{
+				GroupName: "/admin",
+				Audiences: []string{"internal"},
+				Routes:    []Route{{Method: "GET", Path: "/admin/users"}, {Method: "GET", Path: "/admin/status", Audiences: []string{"public"}}},
+				Groups:    []Group{{GroupName: "/reports", Routes: []Route{{Method: "GET", Path: "/admin/reports"}}}},
+			},
+			{GroupName: "/debug", Hidden: true, Routes: []Route{{Method: "GET", Path: "/debug/pprof", Audiences: []string{"internal"}}}},
+			{GroupName: "/empty"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		doc      Doc
+		audience string
+		want     []string
+	}{
+		{
+			name: "Should document every route that is not hidden without an audience",
+			doc:  doc,
+			want: []string{"/users", "/partners", "/admin/users", "/admin/status", "/admin/reports"},
+		},
+		{
+			name:     "Should document the routes of the audience and the ones without audiences",
+			doc:      doc,
+			audience: "public",
+			want:     []string{"/users", "/admin/status"},
+		},
+		{
+			name:     "Should inherit the audiences of the parent groups",
+			doc:      doc,
+			audience: "internal",
+			want:     []string{"/users", "/partners", "/admin/users", "/admin/reports"},
+		},
+		{
+			name:     "Should inherit the audiences of the instance",
+			doc:      Doc{Routes: []Route{{Path: "/a"}, {Path: "/b", Audiences: []string{"public"}}}, Audiences: []string{"internal"}},
+			audience: "public",
+			want:     []string{"/b"},
+		},
+		{
+			name: "Should hide every route of a hidden instance",
+			doc:  Doc{Routes: []Route{{Path: "/a"}}, Groups: []Group{{Routes: []Route{{Path: "/b"}}}}, Hidden: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routes, groups := documentedRoutes(tt.doc, tt.audience)
+
+			var got []string
+			walkRoutes(routes, groups, func(r Route) { got = append(got, r.Path) })
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("Should drop the groups left without routes and keep the empty ones", func(t *testing.T) {
+		_, groups := documentedRoutes(doc, "public")
+
+		var names []string
+		for _, g := range groups {
+			names = append(names, g.GroupName)
+		}
+		assert.Equal(t, []string{"/admin", "/empty"}, names)
+		assert.Nil(t, groups[0].Groups)
+	})
+}
+
+func TestWrite_hidden(t *testing.T) {
+	var b strings.Builder
+
+	_, err := Write(&b, Doc{Routes: []Route{
+		{Method: "GET", Path: "/users"},
+		{Method: "GET", Path: "/health", Hidden: true},
+	}})
+	require.NoError(t, err)
+
+	assert.Contains(t, b.String(), "// @Router /users [get]")
+	assert.NotContains(t, b.String(), "/health")
+}
+
+func TestGenerate_audience(t *testing.T) {
+	doc := Doc{Routes: []Route{
+		{Method: "GET", Path: "/users"},
+		{Method: "GET", Path: "/admin", Audiences: []string{"internal"}},
+	}}
+
+	t.Run("Should write the stub of the audience", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, Generate(doc, WithOutputDir(dir), WithAudience("public"), WithLogger(&testLogger{})))
+
+		content, err := os.ReadFile(filepath.Join(dir, defaultFileName))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "// @Router /users [get]")
+		assert.NotContains(t, string(content), "/admin")
+	})
+
+	t.Run("Should read the audience from the environment", func(t *testing.T) {
+		t.Setenv(AudienceEnv, "public")
+
+		var out bytes.Buffer
+		require.NoError(t, Generate(doc, WithDryRun(&out), WithLogger(&testLogger{})))
+		assert.Equal(t, "GET /users\n", out.String())
+	})
+
+	t.Run("Should write a stub per audience", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, Generate(doc, WithOutputDir(dir), WithAudiences("public", "internal"), WithLogger(&testLogger{})))
+
+		content, err := os.ReadFile(filepath.Join(dir, "public", defaultFileName))
+		require.NoError(t, err)
+		assert.Equal(t, generatedHeader+"package public\n\n// @Router /users [get]\n\n", string(content))
+
+		content, err = os.ReadFile(filepath.Join(dir, "internal", defaultFileName))
+		require.NoError(t, err)
+		assert.Equal(t, generatedHeader+"package internal\n\n// @Router /users [get]\n\n// @Router /admin [get]\n\n", string(content))
+
+		assert.NoFileExists(t, filepath.Join(dir, defaultFileName))
+	})
+
+	t.Run("Should read several audiences from the environment", func(t *testing.T) {
+		t.Setenv(AudienceEnv, "public, internal")
+
+		dir := t.TempDir()
+		require.NoError(t, Generate(doc, WithOutputDir(dir), WithLogger(&testLogger{})))
+
+		assert.FileExists(t, filepath.Join(dir, "public", defaultFileName))
+		assert.FileExists(t, filepath.Join(dir, "internal", defaultFileName))
+	})
+
+	t.Run("Should fail on an audience that can't name a folder", func(t *testing.T) {
+		err := Generate(doc, WithOutputDir(t.TempDir()), WithAudiences("public", "../internal"), WithLogger(&testLogger{}))
+		assert.EqualError(t, err, `audience "../internal" can't name the folder of its stub`)
+	})
+
+	t.Run("Should fail on an audience named like a spec", func(t *testing.T) {
+		specDoc := doc
+		specDoc.Groups = []Group{{GroupName: "/internal", Spec: "internal", Routes: []Route{{Method: "GET", Path: "/internal/jobs"}}}}
+
+		dir := t.TempDir()
+		err := Generate(specDoc, WithOutputDir(dir), WithAudiences("public", "internal"), WithLogger(&testLogger{}))
+		assert.EqualError(t, err, `audience "internal" is also the name of a spec, their stubs would share a folder`)
+		assert.NoDirExists(t, filepath.Join(dir, "public"), "Should fail before writing anything")
+	})
+
+	t.Run("Should replace the stubs of the named specs of a run without audiences", func(t *testing.T) {
+		specDoc := doc
+		specDoc.Groups = []Group{{GroupName: "/internal", Spec: "internal", Routes: []Route{{Method: "GET", Path: "/internal/jobs"}}}}
+
+		dir := t.TempDir()
+		require.NoError(t, Generate(specDoc, WithOutputDir(dir), WithLogger(&testLogger{})))
+		require.NoError(t, Generate(doc, WithOutputDir(dir), WithAudiences("public", "internal"), WithLogger(&testLogger{})))
+		assert.NoFileExists(t, filepath.Join(dir, "goswag.specs"))
+
+		require.NoError(t, Generate(doc, WithOutputDir(dir), WithLogger(&testLogger{})))
+
+		content, err := os.ReadFile(filepath.Join(dir, "internal", defaultFileName))
+		require.NoError(t, err, "Should not remove the stub of the audience")
+		assert.Contains(t, string(content), "package internal\n")
+		assert.Contains(t, string(content), "// @Router /admin [get]")
+	})
+
+	t.Run("Should fail on several audiences with the dry run", func(t *testing.T) {
+		err := Generate(doc, WithAudiences("public", "internal"), WithDryRun(&bytes.Buffer{}), WithLogger(&testLogger{}))
+		assert.EqualError(t, err, "several audiences can't be combined with the manifest or the dry run")
+	})
+}
+
+func TestSplitAudiences(t *testing.T) {
+	tests := []struct {
+		name string
+		list string
+		want []string
+	}{
+		{name: "Should return nil for a single audience", list: "public", want: nil},
+		{name: "Should split a comma-separated list", list: "public,internal", want: []string{"public", "internal"}},
+		{name: "Should trim the audiences and drop the empty and repeated ones", list: " public, ,internal,public,", want: []string{"public", "internal"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SplitAudiences(tt.list))
+		})
+	}
+}
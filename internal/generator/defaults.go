@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/diegoclair/goswag/models"
+)
+
+// RouteDefaults are the documentation a group, or the instance, gives to all
+// of its routes. Nested groups inherit the defaults of their parents.
+type RouteDefaults struct {
+	// Responses are appended to the responses of every route, the ones of
+	// the closest group first. A default is skipped when the route, or a
+	// closer default, already documents its status code, and when the route
+	// opts out of it with SkipDefaultResponses.
+	Responses []models.ReturnType
+	// HeaderParams are added to every route that does not declare a header
+	// param with the same name.
+	HeaderParams []Param
+	// Accepts and Produces are used by the routes that do not set theirs.
+	// Form routes keep accepting the form encoding matching their params.
+	Accepts  []string
+	Produces []string
+}
+
+// inherit returns the defaults of a group whose parent has the given ones:
+// the ones of the group take precedence.
+func (d RouteDefaults) inherit(parent RouteDefaults) RouteDefaults {
+	merged := RouteDefaults{
+		Responses:    append(append([]models.ReturnType{}, d.Responses...), parent.Responses...),
+		HeaderParams: mergeHeaderParams(d.HeaderParams, parent.HeaderParams),
+		Accepts:      d.Accepts,
+		Produces:     d.Produces,
+	}
+
+	if len(merged.Accepts) == 0 {
+		merged.Accepts = parent.Accepts
+	}
+	if len(merged.Produces) == 0 {
+		merged.Produces = parent.Produces
+	}
+
+	return merged
+}
+
+// applyDefaults returns a copy of the routes and groups with the defaults
+// applied: the given ones to the routes, and the ones each group inherits to
+// the routes of the group. The given slices are left untouched so the same
+// Doc can be rendered more than once.
+func applyDefaults(routes []Route, groups []Group, defaults RouteDefaults) ([]Route, []Group) {
+	var (
+		newRoutes []Route
+		newGroups []Group
+	)
+
+	for _, r := range routes {
+		r.declaredResponses = len(r.Returns)
+		r.Returns = mergeResponses(r, defaults.Responses)
+
+		r.HeaderParams = mergeHeaderParams(r.HeaderParams, defaults.HeaderParams)
+
+		if len(r.Accepts) == 0 && len(r.FormParams) == 0 {
+			r.Accepts = defaults.Accepts
+		}
+		if len(r.Produces) == 0 {
+			r.Produces = defaults.Produces
+		}
+
+		newRoutes = append(newRoutes, r)
+	}
+
+	for _, g := range groups {
+		g.Routes, g.Groups = applyDefaults(g.Routes, g.Groups, g.Defaults.inherit(defaults))
+		newGroups = append(newGroups, g)
+	}
+
+	return newRoutes, newGroups
+}
+
+// mergeResponses returns the responses of the route followed by the defaults
+// it does not override nor skip.
+func mergeResponses(r Route, defaults []models.ReturnType) []models.ReturnType {
+	if len(defaults) == 0 || r.SkipAllDefaultResponses {
+		return r.Returns
+	}
+
+	documented := make(map[string]bool, len(r.Returns)+len(defaults))
+	for _, ret := range r.Returns {
+		documented[responseCode(ret)] = true
+	}
+
+	for _, code := range r.SkipDefaultResponses {
+		documented[strconv.Itoa(code)] = true
+	}
+
+	merged := append([]models.ReturnType{}, r.Returns...)
+	for _, ret := range defaults {
+		code := responseCode(ret)
+		if documented[code] {
+			continue
+		}
+
+		documented[code] = true
+		merged = append(merged, ret)
+	}
+
+	return merged
+}
+
+// mergeHeaderParams returns params followed by the inherited params whose
+// name is not in params. Header names are case insensitive.
+func mergeHeaderParams(params, inherited []Param) []Param {
+	if len(inherited) == 0 {
+		return params
+	}
+
+	declared := make(map[string]bool, len(params))
+	for _, p := range params {
+		declared[strings.ToLower(p.Name)] = true
+	}
+
+	merged := append([]Param{}, params...)
+	for _, p := range inherited {
+		if !declared[strings.ToLower(p.Name)] {
+			declared[strings.ToLower(p.Name)] = true
+			merged = append(merged, p)
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,137 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/diegoclair/goswag/internal/generator/testutil"
+	"github.com/diegoclair/goswag/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	auth := Param{Name: "Authorization", ParamType: "string", Required: true}
+	tenant := Param{Name: "X-Tenant", ParamType: "string"}
+	avatar := Param{Name: "avatar", ParamType: FileParamType}
+
+	routes := []Route{{Path: "/health"}}
+	groups := []Group{
+		{
+			Defaults: RouteDefaults{
+				Responses:    []models.ReturnType{{StatusCode: 403}},
+				HeaderParams: []Param{auth},
+				Produces:     []string{"xml"},
+			},
+			Routes: []Route{
+				{Path: "/admin", Returns: []models.ReturnType{{StatusCode: 200}}},
+				{Path: "/admin/token", HeaderParams: []Param{{Name: "authorization", ParamType: "string"}}, Produces: []string{"plain"}},
+			},
+			Groups: []Group{
+				{
+					Defaults: RouteDefaults{
+						Responses:    []models.ReturnType{{StatusCode: 409}},
+						HeaderParams: []Param{tenant},
+						Accepts:      []string{"mpfd"},
+					},
+					Routes: []Route{{Path: "/admin/users"}},
+				},
+			},
+		},
+		{Routes: []Route{
+			{Path: "/public"},
+			{Path: "/public/avatar", FormParams: []Param{avatar}},
+			{Path: "/public/avatar/raw", FormParams: []Param{avatar}, Accepts: []string{"octet-stream"}},
+		}},
+	}
+	defaults := RouteDefaults{Responses: []models.ReturnType{{StatusCode: 500}}, Accepts: []string{"json"}}
+
+	gotRoutes, gotGroups := applyDefaults(routes, groups, defaults)
+
+	assert.Equal(t, Route{
+		Path:    "/health",
+		Returns: []models.ReturnType{{StatusCode: 500}},
+		Accepts: []string{"json"},
+	}, gotRoutes[0], "Should apply the instance defaults")
+
+	assert.Equal(t, Route{
+		Path:              "/admin",
+		Returns:           []models.ReturnType{{StatusCode: 200}, {StatusCode: 403}, {StatusCode: 500}},
+		HeaderParams:      []Param{auth},
+		Accepts:           []string{"json"},
+		Produces:          []string{"xml"},
+		declaredResponses: 1,
+	}, gotGroups[0].Routes[0], "Should apply the group defaults before the instance ones")
+
+	assert.Equal(t, []Param{{Name: "authorization", ParamType: "string"}}, gotGroups[0].Routes[1].HeaderParams,
+		"Should keep the header params declared by the route")
+	assert.Equal(t, []string{"plain"}, gotGroups[0].Routes[1].Produces, "Should keep the produces of the route")
+
+	assert.Equal(t, Route{
+		Path:         "/admin/users",
+		Returns:      []models.ReturnType{{StatusCode: 409}, {StatusCode: 403}, {StatusCode: 500}},
+		HeaderParams: []Param{tenant, auth},
+		Accepts:      []string{"mpfd"},
+		Produces:     []string{"xml"},
+	}, gotGroups[0].Groups[0].Routes[0], "Should inherit the defaults of the parent groups")
+
+	assert.Equal(t, Route{
+		Path:    "/public",
+		Returns: []models.ReturnType{{StatusCode: 500}},
+		Accepts: []string{"json"},
+	}, gotGroups[1].Routes[0], "Should not apply the defaults of sibling groups")
+
+	assert.Equal(t, []string{"mpfd"}, formAccepts(gotGroups[1].Routes[1]), "Should keep the form mime of the form routes")
+	assert.Equal(t, []string{"octet-stream"}, formAccepts(gotGroups[1].Routes[2]), "Should keep the accepts declared by a form route")
+
+	assert.Nil(t, groups[0].Routes[0].HeaderParams, "Should not modify the given groups")
+}
+
+func TestMergeResponses(t *testing.T) {
+	defaults := []models.ReturnType{
+		{StatusCode: 400, Body: testutil.TestGeneric{}},
+		{StatusCode: 401},
+		{StatusCode: 400, Description: "Shadowed by the closer default"},
+		{Default: true, Description: "Unexpected error"},
+	}
+
+	tests := []struct {
+		name  string
+		route Route
+		want  []models.ReturnType
+	}{
+		{
+			name:  "Should append the defaults without the shadowed ones",
+			route: Route{Returns: []models.ReturnType{{StatusCode: 200}}},
+			want: []models.ReturnType{
+				{StatusCode: 200},
+				{StatusCode: 400, Body: testutil.TestGeneric{}},
+				{StatusCode: 401},
+				{Default: true, Description: "Unexpected error"},
+			},
+		},
+		{
+			name:  "Should keep the response of the route over the default with the same code",
+			route: Route{Returns: []models.ReturnType{{StatusCode: 400, Body: testutil.OverrideStruct{}}, {Default: true}}},
+			want: []models.ReturnType{
+				{StatusCode: 400, Body: testutil.OverrideStruct{}},
+				{Default: true},
+				{StatusCode: 401},
+			},
+		},
+		{
+			name:  "Should skip the given codes",
+			route: Route{SkipDefaultResponses: []int{400, 401}},
+			want:  []models.ReturnType{{Default: true, Description: "Unexpected error"}},
+		},
+		{
+			name:  "Should skip all the defaults",
+			route: Route{Returns: []models.ReturnType{{StatusCode: 200}}, SkipAllDefaultResponses: true},
+			want:  []models.ReturnType{{StatusCode: 200}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mergeResponses(tt.route, defaults))
+		})
+	}
+}
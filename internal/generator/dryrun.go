@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DryRunEnv is set by `goswag docs --dry-run` so Generate prints the
+// effective responses of every route instead of writing the stub file.
+const DryRunEnv = "GOSWAG_DRY_RUN"
+
+// WriteResponses writes the effective responses of every route of doc to w,
+// in registration order: the ones declared by the route and the defaults it
+// gets, marked as such, after the overridden and skipped ones are removed.
+func WriteResponses(w io.Writer, doc Doc) error {
+	routes, groups := prepareRoutes(doc)
+
+	cw := &countingWriter{w: w}
+	writeRouteResponses(cw, routes)
+	writeGroupResponses(cw, groups)
+
+	return cw.err
+}
+
+// writeRouteResponses writes the responses of the prepared routes, where
+// the declared responses come first and the inherited defaults after them.
+func writeRouteResponses(w io.Writer, routes []Route) {
+	for _, r := range routes {
+		fmt.Fprintf(w, "%s %s\n", r.Method, r.Path)
+
+		for j, ret := range r.Returns {
+			code := responseCode(ret)
+			if code == "" {
+				continue
+			}
+
+			line := &strings.Builder{}
+			line.WriteString("  " + code)
+
+			if ret.Body != nil {
+				kind, name := bodyResponseKind(ret.Body, nil)
+				line.WriteString(fmt.Sprintf(" {%s} %s", kind, name))
+			}
+
+			if ret.Description != "" {
+				line.WriteString(fmt.Sprintf(" %q", ret.Description))
+			}
+
+			if j >= r.declaredResponses {
+				line.WriteString(" (default)")
+			}
+
+			fmt.Fprintln(w, line.String())
+		}
+	}
+}
+
+func writeGroupResponses(w io.Writer, groups []Group) {
+	for _, g := range groups {
+		writeRouteResponses(w, g.Routes)
+		writeGroupResponses(w, g.Groups)
+	}
+}
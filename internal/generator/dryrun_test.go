@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/diegoclair/goswag/internal/generator/testutil"
+	"github.com/diegoclair/goswag/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var dryRunDoc = Doc{
+	Routes: []Route{
+		{
+			Method: "GET",
+			Path:   "/users/:id",
+			Returns: []models.ReturnType{
+				{StatusCode: 200, Body: testutil.TestGeneric{}},
+				{StatusCode: 404, Description: "User not found"},
+			},
+		},
+		{Method: "GET", Path: "/health", Returns: []models.ReturnType{{StatusCode: 204}}, SkipAllDefaultResponses: true},
+	},
+	Groups: []Group{
+		{
+			Defaults: RouteDefaults{Responses: []models.ReturnType{{StatusCode: 403}}},
+			Routes:   []Route{{Method: "DELETE", Path: "/admin/users/:id", SkipDefaultResponses: []int{401}}},
+		},
+	},
+	DefaultResponses: []models.ReturnType{{StatusCode: 401}, {StatusCode: 404, Body: []testutil.TestGeneric{}}},
+}
+
+func TestWriteResponses(t *testing.T) {
+	var b strings.Builder
+
+	require.NoError(t, WriteResponses(&b, dryRunDoc))
+
+	assert.Equal(t, "GET /users/:id\n"+
+		"  200 {object} testutil.TestGeneric\n"+
+		"  404 \"User not found\"\n"+
+		"  401 (default)\n"+
+		"GET /health\n"+
+		"  204\n"+
+		"DELETE /admin/users/:id\n"+
+		"  403 (default)\n"+
+		"  404 {array} testutil.TestGeneric (default)\n", b.String())
+}
+
+func TestWriteResponses_hiddenRoutes(t *testing.T) {
+	var b strings.Builder
+
+	err := WriteResponses(&b, Doc{
+		Routes: []Route{
+			{Method: "GET", Path: "/debug", Hidden: true, Returns: []models.ReturnType{{StatusCode: 200}, {StatusCode: 500}}},
+			{Method: "GET", Path: "/users"},
+		},
+		Groups: []Group{
+			{GroupName: "/internal", Hidden: true, Routes: []Route{{Method: "GET", Path: "/internal/jobs"}}},
+			{GroupName: "/orders", Routes: []Route{{Method: "GET", Path: "/orders", Returns: []models.ReturnType{{StatusCode: 200}}}}},
+		},
+		DefaultResponses: []models.ReturnType{{StatusCode: 500}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "GET /users\n"+
+		"  500 (default)\n"+
+		"GET /orders\n"+
+		"  200\n"+
+		"  500 (default)\n", b.String(), "Should tell the defaults apart without the hidden routes")
+}
+
+func TestGenerate_dryRun(t *testing.T) {
+	dir := t.TempDir()
+	var b strings.Builder
+
+	err := Generate(dryRunDoc, WithOutputDir(dir), WithDryRun(&b), WithLogger(&testLogger{}))
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(b.String(), "GET /users/:id\n"))
+
+	_, err = os.Stat(filepath.Join(dir, defaultFileName))
+	assert.True(t, os.IsNotExist(err), "Should not write the stub file")
+}
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// ExampleObject is an OpenAPI named example.
+type ExampleObject struct {
+	Value json.RawMessage `json:"value"`
+}
+
+// readExampleNames names the request examples, which are not named when
+// declared: example, example_2, example_3...
+func readExampleNames(examples []any) map[string]any {
+	if len(examples) == 0 {
+		return nil
+	}
+
+	named := make(map[string]any, len(examples))
+	for i, example := range examples {
+		name := "example"
+		if i > 0 {
+			name += "_" + strconv.Itoa(i+1)
+		}
+		named[name] = example
+	}
+
+	return named
+}
+
+// openAPIExamples encodes the named examples as JSON. The ones that can't be
+// encoded are skipped: Validate reports them and Generate fails on them.
+func openAPIExamples(examples map[string]any) map[string]*ExampleObject {
+	if len(examples) == 0 {
+		return nil
+	}
+
+	objects := make(map[string]*ExampleObject, len(examples))
+	for name, example := range examples {
+		value, err := json.Marshal(example)
+		if err != nil {
+			continue
+		}
+		objects[name] = &ExampleObject{Value: value}
+	}
+
+	return objects
+}
+
+// matchExample checks that example, once encoded as JSON, decodes into the
+// type of body, with the rules of json.Unmarshal, without unknown fields or
+// mismatched types. This way the examples kept next to the code break the
+// generation when the body changes.
+func matchExample(example, body any) error {
+	if isStatic(body) {
+		return nil
+	}
+
+	value, err := json.Marshal(example)
+	if err != nil {
+		return fmt.Errorf("can't be encoded as JSON: %w", err)
+	}
+
+	bodyType := reflect.TypeOf(body)
+	for bodyType.Kind() == reflect.Pointer {
+		bodyType = bodyType.Elem()
+	}
+
+	if bodyType.Kind() == reflect.Slice && bodyType.Elem().Kind() == reflect.Uint8 {
+		// file downloads have no JSON shape to check
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(value))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(reflect.New(bodyType).Interface()); err != nil {
+		return fmt.Errorf("does not match %s: %w", bodyType, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/diegoclair/goswag/internal/generator/testutil"
+	"github.com/diegoclair/goswag/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchExample(t *testing.T) {
+	tests := []struct {
+		name    string
+		example any
+		body    any
+		wantErr string
+	}{
+		{
+			name:    "Should match a value of the body type",
+			example: testutil.TestGeneric{Name: "John"},
+			body:    testutil.TestGeneric{},
+		},
+		{
+			name:    "Should match a map with the fields of the body, through pointers",
+			example: map[string]any{"Name": "John"},
+			body:    &testutil.TestGeneric{},
+		},
+		{
+			name:    "Should match a slice of the element type",
+			example: []testutil.TestGeneric{{Name: "John"}},
+			body:    []testutil.TestGeneric{},
+		},
+		{
+			name:    "Should not check file downloads",
+			example: "binary",
+			body:    []byte{},
+		},
+		{
+			name:    "Should report unknown fields",
+			example: map[string]any{"Name": "John", "Age": 30},
+			body:    testutil.TestGeneric{},
+			wantErr: `does not match testutil.TestGeneric: json: unknown field "Age"`,
+		},
+		{
+			name:    "Should report mismatched types",
+			example: map[string]any{"Name": 30},
+			body:    testutil.TestGeneric{},
+			wantErr: "does not match testutil.TestGeneric: json: cannot unmarshal number into Go struct field TestGeneric.Name of type string",
+		},
+		{
+			name:    "Should report examples that are not JSON",
+			example: func() {},
+			body:    testutil.TestGeneric{},
+			wantErr: "can't be encoded as JSON: json: unsupported type: func()",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := matchExample(tt.example, tt.body)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestBuildOpenAPI_examples(t *testing.T) {
+	doc := BuildOpenAPI(Doc{Routes: []Route{{
+		Method:       "POST",
+		Path:         "/users",
+		Reads:        testutil.TestGeneric{},
+		ReadExamples: []any{testutil.TestGeneric{Name: "John"}, map[string]any{"Name": "Jane"}},
+		Returns: []models.ReturnType{{
+			StatusCode: 201,
+			Body:       testutil.TestGeneric{},
+			Examples:   map[string]any{"created": testutil.TestGeneric{Name: "John"}},
+		}},
+	}}})
+
+	op := (*doc.Paths["/users"])["post"]
+	assert.Equal(t, map[string]*ExampleObject{
+		"example":   {Value: json.RawMessage(`{"Name":"John"}`)},
+		"example_2": {Value: json.RawMessage(`{"Name":"Jane"}`)},
+	}, op.RequestBody.Content["application/json"].Examples)
+	assert.Equal(t, map[string]*ExampleObject{
+		"created": {Value: json.RawMessage(`{"Name":"John"}`)},
+	}, op.Responses["201"].Content["application/json"].Examples)
+}
@@ -0,0 +1,34 @@
+package generator
+
+import (
+	"net/http"
+	"strings"
+)
+
+// FormParams derives the form params of a struct from its `form` tags, the
+// ones used by gin's ShouldBind and echo's Bind. Untagged fields are skipped,
+// *multipart.FileHeader fields become file params and a `required` rule in the
+// binding or validate tags marks the param as required.
+func FormParams(v any) []Param {
+	return ParamsOf(v, http.MethodPost).Form
+}
+
+// formAccepts returns the mime types a route accepts: the declared ones or,
+// for routes with form params, the form encoding matching them.
+func formAccepts(r Route) []string {
+	if len(r.Accepts) > 0 && strings.TrimSpace(r.Accepts[0]) != "" {
+		return r.Accepts
+	}
+
+	if len(r.FormParams) == 0 {
+		return nil
+	}
+
+	for _, p := range r.FormParams {
+		if p.ParamType == FileParamType {
+			return []string{"mpfd"}
+		}
+	}
+
+	return []string{"x-www-form-urlencoded"}
+}
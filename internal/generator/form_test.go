@@ -0,0 +1,102 @@
+package generator
+
+import (
+	"mime/multipart"
+	"testing"
+	"time"
+
+	"github.com/diegoclair/goswag/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type formPagination struct {
+	Page int `form:"page"`
+}
+
+type uploadForm struct {
+	formPagination
+	Title       string                  `form:"title" binding:"required" description:"Title of the document"`
+	Tags        []string                `form:"tags"`
+	Public      *bool                   `form:"public"`
+	PublishedAt time.Time               `form:"published_at"`
+	Score       float64                 `form:"score" validate:"omitempty,required"`
+	File        *multipart.FileHeader   `form:"file" binding:"required"`
+	Attachments []*multipart.FileHeader `form:"attachments"`
+	Ignored     string                  `form:"-"`
+	Untagged    string
+	unexported  string `form:"unexported"` //nolint:unused
+}
+
+func TestFormParams(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+		want []Param
+	}{
+		{
+			name: "Should derive params from the form tags",
+			data: &uploadForm{},
+			want: []Param{
+				{Name: "page", ParamType: "integer"},
+				{Name: "title", Description: "Title of the document", ParamType: "string", Required: true},
+				{Name: "tags", ParamType: ArrayParamType, ParamAttributes: models.ParamAttributes{Items: "string", CollectionFormat: "multi"}},
+				{Name: "public", ParamType: "boolean"},
+				{Name: "published_at", ParamType: "string", ParamAttributes: models.ParamAttributes{Format: "date-time"}},
+				{Name: "score", ParamType: "number", Required: true},
+				{Name: "file", ParamType: FileParamType, Required: true},
+				{Name: "attachments", ParamType: FileParamType},
+			},
+		},
+		{
+			name: "Should return nil for non struct values",
+			data: map[string]string{},
+			want: nil,
+		},
+		{
+			name: "Should return nil for nil",
+			data: nil,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FormParams(tt.data))
+		})
+	}
+}
+
+func TestFormAccepts(t *testing.T) {
+	tests := []struct {
+		name  string
+		route Route
+		want  []string
+	}{
+		{
+			name:  "Should keep the declared accepts",
+			route: Route{Accepts: []string{"json"}, FormParams: []Param{{Name: "a", ParamType: FileParamType}}},
+			want:  []string{"json"},
+		},
+		{
+			name:  "Should accept multipart when there is a file",
+			route: Route{FormParams: []Param{{Name: "a", ParamType: "string"}, {Name: "b", ParamType: FileParamType}}},
+			want:  []string{"mpfd"},
+		},
+		{
+			name:  "Should accept url encoded forms without files",
+			route: Route{FormParams: []Param{{Name: "a", ParamType: "string"}}},
+			want:  []string{"x-www-form-urlencoded"},
+		},
+		{
+			name:  "Should return nil without accepts and form params",
+			route: Route{},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formAccepts(tt.route))
+		})
+	}
+}
@@ -1,124 +1,436 @@
 package generator
 
 import (
+	"bytes"
+	"cmp"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"os"
-	"reflect"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/diegoclair/goswag/models"
 )
 
-const fileName = "goswag.go"
+// OpenAPIOutputEnv is set by `goswag docs --native` to the directory where
+// Generate must also write the OpenAPI 3.1 document, so the CLI does not
+// need the swag binary to produce the spec.
+const OpenAPIOutputEnv = "GOSWAG_OPENAPI_OUTPUT"
+
+const (
+	// FileParamType is the data type of a multipart file upload param.
+	FileParamType = "file"
+	// ArrayParamType is the data type of a param holding a list of values,
+	// whose elements are described by ParamAttributes.Items.
+	ArrayParamType = "array"
+)
 
 type Param struct {
 	Name        string
 	Description string
 	ParamType   string
 	Required    bool
+	models.ParamAttributes
 }
 
 type Route struct {
 	Path         string
 	Method       string
 	FuncName     string // it will be used to generate the function on the goswag.go file
+	Handler      string // the runtime name of the handler, e.g. github.com/foo/api.(*Handler).getUser
+	Source       string // where the route is registered, file:line
 	Summary      string
 	Description  string
 	Tags         []string
 	Accepts      []string
 	Produces     []string
 	Reads        any
-	Returns      []models.ReturnType // example: map[statusCode]responseBody
-	QueryParams  []Param
-	HeaderParams []Param
-	PathParams   []Param
+	ReadExamples []any // examples of the Reads body
+	// ReadOverrides overrides fields of the Reads body, like the
+	// OverrideStructFields of a response.
+	ReadOverrides map[string]any
+	Returns       []models.ReturnType // example: map[statusCode]responseBody
+	QueryParams   []Param
+	HeaderParams  []Param
+	PathParams    []Param
+	FormParams    []Param // formData params, ParamType is FileParamType for uploads
+	Security      []SecurityRequirement
+	Public        bool // opts out of the security of the group and the instance
+	OperationID   string
+	Deprecated    bool
+	// DeprecationReason and Sunset are written as the x-deprecated-reason and
+	// x-sunset extensions of deprecated routes.
+	DeprecationReason string
+	Sunset            time.Time
+	Extensions        map[string]any // vendor extensions, the x- prefix is optional
+	// SkipDefaultResponses are the status codes of the default responses the
+	// route does not get, SkipAllDefaultResponses opts out of all of them.
+	SkipDefaultResponses    []int
+	SkipAllDefaultResponses bool
+	Hidden                  bool     // left out of every stub and spec
+	Audiences               []string // the audiences documenting the route, all of them if empty
+
+	// declaredResponses is the number of Returns declared by the route, the
+	// ones before the defaults applyDefaults appends.
+	declaredResponses int
 }
 
 type Group struct {
 	GroupName string
 	Routes    []Route
 	Groups    []Group
+	Security  []SecurityRequirement // inherited by the routes and groups that declare none
+	Tag       *models.Tag           // replaces the default tag of the routes of the group
+	Defaults  RouteDefaults         // inherited by the routes and the nested groups
+	Hidden    bool                  // hides the routes and the nested groups
+	Audiences []string              // inherited by the routes and groups that declare none
+	Spec      string                // the spec of its routes, inherited by the nested groups
+}
+
+// Doc is everything a framework wrapper collected while the routes were
+// registered, and the input of every generator.
+type Doc struct {
+	Routes           []Route
+	Groups           []Group
+	DefaultResponses []models.ReturnType   // the responses given to NewEcho and NewGin
+	Defaults         RouteDefaults         // the defaults set on the instance, after DefaultResponses
+	Security         []SecurityRequirement // required by every route that declares none
+	SecuritySchemes  map[string]models.SecurityScheme
+	Info             models.Info
+	Tag              *models.Tag     // the default tag of the instance routes
+	TagNamer         models.TagNamer // names the default tag of the groups, DefaultTagName if nil
+	Hidden           bool            // hides every route
+	Audiences        []string        // the audiences of the routes that declare none
+	Spec             string          // the spec of the routes outside groups with a spec, "" for the default one
+	SpecInfo         map[string]models.Info
+}
+
+// Generate validates doc and writes the annotated stub file it describes,
+// honouring the output directory, file name and package name options.
+// The routes of named specs are written to a stub of their own, in the
+// folder of the spec inside the output directory. With several audiences,
+// each of them gets the stubs of its routes in a folder of its own.
+func Generate(doc Doc, opts ...Option) error {
+	cfg := newConfig(opts...)
+
+	// the doc is validated as a whole, before it is split in specs, so an
+	// operation id can't be reused by the route of another spec
+	findings := Validate(doc)
+
+	for _, f := range findings {
+		cfg.Logger.Printf("%s", f)
+	}
+
+	if hasBlocking(findings) || cfg.Strict && hasErrors(findings) {
+		return &ValidationError{Findings: findings}
+	}
+
+	if len(cfg.Audiences) == 0 {
+		return generate(doc, cfg)
+	}
+
+	if err := checkAudiences(cfg.Audiences, doc); err != nil {
+		return err
+	}
+
+	if cfg.Manifest != nil || os.Getenv(ManifestEnv) != "" || cfg.DryRun != nil {
+		return fmt.Errorf("several audiences can't be combined with the manifest or the dry run")
+	}
+
+	// the named specs of a run without audiences are not written anymore, and
+	// their record must not make a later run remove the stubs of audiences
+	if err := removeStaleSpecs(cfg.OutputDir, cfg.FileName, nil, cfg.Logger); err != nil {
+		return err
+	}
+
+	for _, audience := range cfg.Audiences {
+		audienceCfg := *cfg
+		audienceCfg.Audience, audienceCfg.Audiences = audience, nil
+		audienceCfg.OutputDir = filepath.Join(cfg.OutputDir, audience)
+		audienceCfg.PackageName = specPackageName(audience)
+		if cfg.OpenAPIDir != "" {
+			audienceCfg.OpenAPIDir = filepath.Join(cfg.OpenAPIDir, audience)
+		}
+
+		if err := generate(doc, &audienceCfg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generate writes the stubs of the routes of doc documented for the
+// audience of cfg, or what the manifest or dry run options ask for instead.
+func generate(doc Doc, cfg *Config) error {
+	doc.Routes, doc.Groups = documentedRoutes(doc, cfg.Audience)
+
+	if cfg.Manifest != nil {
+		return WriteManifest(cfg.Manifest, doc)
+	}
+
+	if path := os.Getenv(ManifestEnv); path != "" {
+		return writeManifestFile(path, doc)
+	}
+
+	if cfg.DryRun != nil {
+		return WriteResponses(cfg.DryRun, doc)
+	}
+
+	specs := Specs(doc)
+	for _, spec := range specs {
+		if err := generateSpec(spec, cfg); err != nil {
+			return err
+		}
+	}
+
+	return removeStaleSpecs(cfg.OutputDir, cfg.FileName, specs, cfg.Logger)
+}
+
+// generateSpec writes the stub of a spec and, when `goswag docs --native`
+// asks for it, its OpenAPI document.
+func generateSpec(spec Spec, cfg *Config) error {
+	dir, specCfg := cfg.OutputDir, *cfg
+	if spec.Name != "" {
+		dir = filepath.Join(cfg.OutputDir, spec.Name)
+		specCfg.PackageName = specPackageName(spec.Name)
+	}
+	path := filepath.Join(dir, cfg.FileName)
+
+	cfg.Logger.Printf("Generating %s file...", path)
+
+	reserved, err := packageDeclarations(dir, cfg.FileName, specCfg.PackageName)
+	if err != nil {
+		return err
+	}
+
+	var content bytes.Buffer
+	if _, err := write(&content, spec.Doc, &specCfg, reserved); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, content.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+
+	cfg.Logger.Printf("%s file generated successfully!", path)
+
+	if cfg.OpenAPIDir != "" {
+		openAPIDir := filepath.Join(cfg.OpenAPIDir, spec.Name)
+		if err := GenerateOpenAPI(spec.Doc, openAPIDir); err != nil {
+			return err
+		}
+
+		cfg.Logger.Printf("OpenAPI document generated successfully at %s!", openAPIDir)
+	}
+
+	return nil
 }
 
-func GenerateSwagger(routes []Route, groups []Group, defaultResponses []models.ReturnType) {
+// Write writes the content of the annotated stub file described by doc to w.
+func Write(w io.Writer, doc Doc, opts ...Option) (int64, error) {
+	return write(w, doc, newConfig(opts...), nil)
+}
+
+// write writes the stub of doc to w. Its types don't reuse the reserved
+// names, the declarations of the package it is written to.
+func write(w io.Writer, doc Doc, cfg *Config, reserved map[string]bool) (int64, error) {
+	packagesToImport := make(map[string]bool)
+
+	routes, groups := prepareRoutes(doc)
+	routes, groups, declared := declareAnonymousTypes(routes, groups, reserved)
+
+	// the first pass collects the packages to import, which the second one
+	// needs to qualify the types of packages sharing a name with an alias
 	var (
-		packagesToImport = make(map[string]bool)
-		fullFileContent  = &strings.Builder{}
+		content, declarations string
+		used                  map[string]string
 	)
+	for range 2 {
+		content = writeContent(doc, routes, groups, packagesToImport)
+		declarations, used = declared.write(packagesToImport)
+	}
+
+	cw := &countingWriter{w: w}
+	writeFileContent(cw, cfg.PackageName, declarations+content, packagesToImport, used)
+
+	return cw.n, cw.err
+}
 
-	log.Printf("Generating %s file...", fileName)
+// writeContent renders the annotations of the stub, adding the packages
+// they refer to to packagesToImport.
+func writeContent(doc Doc, routes []Route, groups []Group, packagesToImport map[string]bool) string {
+	fullFileContent := &strings.Builder{}
 
-	routes, groups = addDefaultResponses(routes, groups, defaultResponses)
+	writeInfo(fullFileContent, doc.Info)
+	writeTags(fullFileContent, declaredTags(doc))
+	writeSecurityDefinitions(fullFileContent, doc.SecuritySchemes)
 
 	if routes != nil {
-		writeRoutes("", routes, fullFileContent, packagesToImport)
+		writeRoutes(routes, fullFileContent, packagesToImport)
 	}
 
 	if groups != nil {
 		writeGroup(groups, fullFileContent, packagesToImport)
 	}
 
-	f, err := os.Create(fmt.Sprintf("./%s", fileName))
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer f.Close()
+	return fullFileContent.String()
+}
 
-	writeFileContent(f, fullFileContent.String(), packagesToImport)
+// prepareRoutes returns the routes and groups of doc as they are documented,
+// without the hidden ones and with the default responses, the inherited security, the default tags and
+// the default operation ids applied.
+func prepareRoutes(doc Doc) ([]Route, []Group) {
+	defaults := doc.Defaults
+	defaults.Responses = append(append([]models.ReturnType{}, doc.DefaultResponses...), defaults.Responses...)
 
-	log.Printf("%s file generated successfully!", fileName)
+	routes, groups := documentedRoutes(doc, "")
+	routes, groups = applyDefaults(routes, groups, defaults)
+	routes, groups = resolveSecurity(routes, groups, doc.Security)
+	routes, groups = applyTags(routes, groups, doc.Tag, doc.TagNamer)
+
+	return assignOperationIDs(routes, groups)
 }
 
-// addDefaultResponses adds the default responses to the routes and groups if it are not empty
-func addDefaultResponses(routes []Route, groups []Group, defaultResponses []models.ReturnType) ([]Route, []Group) {
-	if len(defaultResponses) == 0 {
-		return routes, groups
-	}
+// countingWriter counts the bytes written and keeps the first error, so the
+// fmt.Fprintf calls that build the file don't have to be checked one by one.
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
 
-	for i := range routes {
-		routes[i].Returns = append(routes[i].Returns, defaultResponses...)
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
 	}
 
-	for i := range groups {
-		groups[i].Routes, groups[i].Groups = addDefaultResponses(groups[i].Routes, groups[i].Groups, defaultResponses)
-	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
 
-	return routes, groups
+	return n, err
 }
 
-func writeFileContent(file io.Writer, content string, packagesToImport map[string]bool) {
-	fmt.Fprintf(file, "package main\n\n")
+// generatedHeader marks the stub as generated code, so linters and code
+// review tools skip it and `goswag docs --check` can recognise it.
+const generatedHeader = "// Code generated by goswag. DO NOT EDIT.\n\n"
+
+// writeFileContent writes the stub: its imports and content. used are the
+// names the packages are used with by the types declared in content, which
+// can't be blank imports.
+func writeFileContent(file io.Writer, packageName, content string, packagesToImport map[string]bool, used map[string]string) {
+	fmt.Fprint(file, generatedHeader)
+	fmt.Fprintf(file, "package %s\n\n", packageName)
+
+	var uses []string
 
 	if len(packagesToImport) > 0 {
 		fmt.Fprintf(file, "import (\n")
 
-		for pkg := range packagesToImport {
-			fmt.Fprintf(file, "\t_ \"%s\"\n", pkg)
+		for _, pkg := range sortedKeys(packagesToImport) {
+			alias := importAlias(pkg, packagesToImport)
+
+			if name, ok := used[pkg]; ok {
+				if name == packageNameFromPath(pkg) {
+					fmt.Fprintf(file, "\t\"%s\"\n", pkg)
+				} else {
+					fmt.Fprintf(file, "\t%s \"%s\"\n", name, pkg)
+				}
+				continue
+			}
+
+			use := aliasUse(alias, content)
+			if use == "" {
+				fmt.Fprintf(file, "\t_ \"%s\"\n", pkg)
+				continue
+			}
+
+			fmt.Fprintf(file, "\t%s \"%s\"\n", alias, pkg)
+			uses = append(uses, use)
 		}
 
 		fmt.Fprintf(file, ")\n\n")
 	}
 
+	// an aliased import must be used for the stub to compile
+	for _, use := range uses {
+		fmt.Fprintf(file, "var _ *%s\n", use)
+	}
+	if len(uses) > 0 {
+		fmt.Fprintln(file)
+	}
+
 	fmt.Fprintf(file, "%s", content)
 }
 
-func writeRoutes(groupName string, routes []Route, s *strings.Builder, packagesToImport map[string]bool) {
+// aliasUse returns a type of the package imported as alias that the
+// annotations in content refer to, to declare a variable of: the first one
+// that is not generic or, when there is none, the first instance of a
+// generic one, as written. It is "" when alias is.
+func aliasUse(alias, content string) string {
+	if alias == "" {
+		return ""
+	}
+
+	re := regexp.MustCompile(`\b` + regexp.QuoteMeta(alias) + `\.[A-Za-z_][A-Za-z0-9_]*`)
+
+	var generic string
+	for _, loc := range re.FindAllStringIndex(content, -1) {
+		end := loc[1]
+		if end >= len(content) || content[end] != '[' {
+			return content[loc[0]:end]
+		}
+
+		if generic == "" {
+			generic = content[loc[0] : end+bracketsLen(content[end:])]
+		}
+	}
+
+	return generic
+}
+
+// bracketsLen returns the length of the bracketed type arguments s starts with.
+func bracketsLen(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+
+	return len(s)
+}
+
+func writeRoutes(routes []Route, s *strings.Builder, packagesToImport map[string]bool) {
 	for _, r := range routes {
 		addLineIfNotEmpty(s, r.Summary, "// @Summary %s\n")
 		addTextIfNotEmptyOrDefault(s, r.Summary, "// @Description %s\n", r.Description)
 
 		if len(r.Tags) > 0 {
 			s.WriteString(fmt.Sprintf("// @Tags %s\n", strings.Join(r.Tags, ",")))
-		} else if groupName != "" {
-			s.WriteString(fmt.Sprintf("// @Tags %s\n", groupName))
 		}
 
-		if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		writeOperation(s, r)
+
+		if r.Method == http.MethodPost || r.Method == http.MethodPut || len(r.FormParams) > 0 {
 			// methods like get or delete do not have a request body
-			addTextIfNotEmptyOrDefault(s, "json", "// @Accept %s\n", r.Accepts...)
+			addTextIfNotEmptyOrDefault(s, "json", "// @Accept %s\n", formAccepts(r)...)
 		}
 
 		if r.Returns != nil {
@@ -127,32 +439,22 @@ func writeRoutes(groupName string, routes []Route, s *strings.Builder, packagesT
 		}
 
 		if r.Reads != nil {
-			s.WriteString(fmt.Sprintf("// @Param request body %s true \"Request\"\n", getStructAndPackageName(r.Reads)))
-			addBodyPackageToImport(r.Reads, packagesToImport)
-		}
-
-		for _, param := range r.PathParams {
-			s.WriteString(fmt.Sprintf("// @Param %s path %s %t \"%s\"\n",
-				param.Name, param.ParamType, param.Required, param.Description),
-			)
+			s.WriteString("// @Param request body " + bodyRequestTypeName(r.Reads, packagesToImport))
+			writeOverrides(s, r.Reads, r.ReadOverrides, packagesToImport)
+			s.WriteString(" true \"Request\"\n")
 		}
 
-		for _, param := range r.QueryParams {
-			s.WriteString(fmt.Sprintf("// @Param %s query %s %t \"%s\"\n",
-				param.Name, param.ParamType, param.Required, param.Description),
-			)
-		}
-
-		for _, param := range r.HeaderParams {
-			s.WriteString(fmt.Sprintf("// @Param %s header %s %t \"%s\"\n",
-				param.Name, param.ParamType, param.Required, param.Description),
-			)
-		}
+		writeParams(s, "path", r.PathParams)
+		writeParams(s, "query", r.QueryParams)
+		writeParams(s, "header", r.HeaderParams)
+		writeParams(s, "formData", r.FormParams)
 
 		if r.Returns != nil {
 			writeReturns(r.Returns, s, packagesToImport)
 		}
 
+		writeSecurity(s, r.Security)
+
 		if r.Path != "" {
 			s.WriteString(fmt.Sprintf("// @Router %s [%s]\n", r.Path, strings.ToLower(r.Method)))
 		}
@@ -165,154 +467,140 @@ func writeRoutes(groupName string, routes []Route, s *strings.Builder, packagesT
 	}
 }
 
-func writeReturns(returns []models.ReturnType, s *strings.Builder, packagesToImport map[string]bool) {
-	for _, data := range returns {
-		if data.StatusCode == 0 {
-			continue
+func writeParams(s *strings.Builder, in string, params []Param) {
+	for _, param := range params {
+		paramType := param.ParamType
+		if paramType == ArrayParamType {
+			paramType = "[]" + cmp.Or(param.Items, "string")
 		}
 
-		respType := "@Success"
-		firstDigit := data.StatusCode / 100
-
-		if firstDigit != http.StatusOK/100 { // <> 2xx
-			respType = "@Failure"
-		}
-
-		if data.Body == nil {
-			s.WriteString(fmt.Sprintf("// %s %d\n", respType, data.StatusCode))
-			continue
-		}
-
-		var isGeneric bool = writeIfIsGenericType(s, data, respType)
-
-		if !isGeneric {
-			// if it is not a generic type, we can write the response normally
-			s.WriteString(fmt.Sprintf("// %s %d {object} %s", respType, data.StatusCode, getStructAndPackageName(data.Body)))
-		}
-
-		addPackageToImport(data, packagesToImport)
-		handleOverrideStructFields(s, data)
-
-		s.WriteString("\n")
+		s.WriteString(fmt.Sprintf("// @Param %s %s %s %t \"%s\"%s\n",
+			param.Name, in, paramType, param.Required, quotedText(param.Description), paramAttributes(param)),
+		)
 	}
 }
 
-func writeGroup(groups []Group, s *strings.Builder, packagesToImport map[string]bool) {
-	for _, g := range groups {
-		writeRoutes(g.GroupName, g.Routes, s, packagesToImport)
+// quotedText returns s for the quoted descriptions of the swag annotations,
+// which have no escaping: its double quotes are written as single quotes.
+// The native OpenAPI document keeps them.
+func quotedText(s string) string {
+	return strings.ReplaceAll(s, `"`, "'")
+}
 
-		if g.Groups != nil {
-			writeGroup(g.Groups, s, packagesToImport)
+// paramAttributes renders the swag attributes of a param, prefixed by a space.
+func paramAttributes(p Param) string {
+	var attrs []string
+
+	if len(p.Enum) > 0 {
+		values := make([]string, 0, len(p.Enum))
+		for _, v := range p.Enum {
+			values = append(values, fmt.Sprint(v))
 		}
+		attrs = append(attrs, fmt.Sprintf("Enums(%s)", strings.Join(values, ", ")))
 	}
-}
 
-// addBodyPackageToImport adds the package of the given body type to the import map.
-func addBodyPackageToImport(body any, packagesToImport map[string]bool) {
-	if body == nil {
-		return
+	if p.CollectionFormat != "" {
+		attrs = append(attrs, fmt.Sprintf("collectionFormat(%s)", p.CollectionFormat))
 	}
-	t := reflect.TypeOf(body)
-	if t.Kind() == reflect.Pointer {
-		t = t.Elem()
+	if p.Default != nil {
+		attrs = append(attrs, fmt.Sprintf("default(%v)", p.Default))
 	}
-	if t.PkgPath() != "" {
-		packagesToImport[t.PkgPath()] = true
+	if p.Minimum != nil {
+		attrs = append(attrs, fmt.Sprintf("minimum(%s)", strconv.FormatFloat(*p.Minimum, 'f', -1, 64)))
+	}
+	if p.Maximum != nil {
+		attrs = append(attrs, fmt.Sprintf("maximum(%s)", strconv.FormatFloat(*p.Maximum, 'f', -1, 64)))
+	}
+	if p.MinLength != nil {
+		attrs = append(attrs, fmt.Sprintf("minLength(%d)", *p.MinLength))
+	}
+	if p.MaxLength != nil {
+		attrs = append(attrs, fmt.Sprintf("maxLength(%d)", *p.MaxLength))
+	}
+	if p.Format != "" {
+		attrs = append(attrs, fmt.Sprintf("format(%s)", p.Format))
+	}
+	if p.Example != nil {
+		attrs = append(attrs, fmt.Sprintf("example(%v)", p.Example))
 	}
-}
-
-// addPackageToImport adds the package to import.
-func addPackageToImport(data models.ReturnType, packagesToImport map[string]bool) {
-	addBodyPackageToImport(data.Body, packagesToImport)
-}
-
-// writeIfIsGenericType writes the correctly response type if it is a generic type
-// and returns the packages to import that need to be added to the goswag.go file to make it work
-func writeIfIsGenericType(s *strings.Builder, data models.ReturnType, respType string) (isGeneric bool) {
-	bodyName := getStructAndPackageName(data.Body)
 
-	// generic last character here will be ']'
-	// testutil.StructGeneric[testutil.TestGeneric]
-	isGeneric = bodyName[len(bodyName)-1:] == "]"
-	if !isGeneric {
-		return
+	if len(attrs) == 0 {
+		return ""
 	}
 
-	isArray := strings.Contains(bodyName, "[[]")
-	hasSlash := strings.Contains(bodyName, "/")
+	return " " + strings.Join(attrs, " ")
+}
 
-	if isArray && hasSlash {
-		// example: testutil.StructGeneric[[]github.com/diegoclair/goswag/internal/generator/testutil.TestGeneric]
+func writeReturns(returns []models.ReturnType, s *strings.Builder, packagesToImport map[string]bool) {
+	for _, data := range returns {
+		code := responseCode(data)
+		if code == "" {
+			continue
+		}
 
-		bodyRemovedLastChar := bodyName[:len(bodyName)-1] // testutil.StructGeneric[[]github.com/diegoclair/goswag/internal/generator/testutil.TestGeneric
+		respType := "@Success"
+		if data.Default || data.StatusCode >= http.StatusBadRequest {
+			// 1xx and 3xx are not failures, only client and server errors are
+			respType = "@Failure"
+		}
 
-		// get the last text after '/'
-		str := strings.Split(bodyRemovedLastChar, "/")
-		insideGenericsFullName := str[len(str)-1] // testutil.TestGeneric
+		s.WriteString(fmt.Sprintf("// %s %s", respType, code))
 
-		insidePkg := strings.Split(bodyRemovedLastChar, "[[]")[1]                 // github.com/diegoclair/goswag/internal/generator/testutil.TestGeneric
-		removedType := strings.Replace(insidePkg, insideGenericsFullName, "", -1) // github.com/diegoclair/goswag/internal/generator/
+		if data.Body != nil {
+			kind, name := bodyResponseKind(data.Body, packagesToImport)
+			s.WriteString(fmt.Sprintf(" {%s} %s", kind, name))
+			writeOverrides(s, data.Body, data.OverrideStructFields, packagesToImport)
+		}
 
-		correctlyResponseType := strings.Replace(bodyName, removedType, "", -1) // remove full package from the struct name
+		if data.Description != "" {
+			s.WriteString(fmt.Sprintf(" \"%s\"", quotedText(data.Description)))
+		}
 
-		s.WriteString(fmt.Sprintf("// %s %d {object} %s", respType, data.StatusCode, correctlyResponseType))
+		s.WriteString("\n")
 
-		return isGeneric
+		for _, h := range data.Headers {
+			s.WriteString(fmt.Sprintf("// @Header %s {%s} %s \"%s\"\n",
+				code, paramSchema(h.Type).Type, h.Name, quotedText(h.Description)),
+			)
+		}
 	}
+}
 
-	if hasSlash {
-		// example: testutil.StructGeneric[github.com/diegoclair/goswag/internal/generator/testutil.TestGeneric]
-
-		bodyRemovedLastChar := bodyName[:len(bodyName)-1] // testutil.StructGeneric[github.com/diegoclair/goswag/internal/generator/testutil.TestGeneric
-
-		// get the last text after '/'
-		str := strings.Split(bodyRemovedLastChar, "/")
-		insideGenericsFullName := str[len(str)-1] // testutil.TestGeneric
-
-		insidePkg := strings.Split(bodyRemovedLastChar, "[")[1]                   // github.com/diegoclair/goswag/internal/generator/testutil.TestGeneric
-		removedType := strings.Replace(insidePkg, insideGenericsFullName, "", -1) // github.com/diegoclair/goswag/internal/generator/
-
-		correctlyResponseType := strings.Replace(bodyName, removedType, "", -1) // remove full package from the struct name
-
-		s.WriteString(fmt.Sprintf("// %s %d {object} %s", respType, data.StatusCode, correctlyResponseType))
-
-		return isGeneric
+// responseCode returns the key of a response: its status code, "default" for
+// the catch-all response, or "" when the response has neither.
+func responseCode(data models.ReturnType) string {
+	if data.Default {
+		return "default"
 	}
 
-	// example: genericStruct[int] or genericStruct[string] or genericStruct[bool]
-	// primitive types do not need to import packages
-
-	s.WriteString(fmt.Sprintf("// %s %d {object} %s", respType, data.StatusCode, bodyName))
+	if data.StatusCode == 0 {
+		return ""
+	}
 
-	return isGeneric
+	return strconv.Itoa(data.StatusCode)
 }
 
-func handleOverrideStructFields(s *strings.Builder, data models.ReturnType) {
-	if data.OverrideStructFields != nil {
-		i := 0
-		for key, object := range data.OverrideStructFields {
-			if i == 0 {
-				s.WriteString("{")
-			}
+func writeGroup(groups []Group, s *strings.Builder, packagesToImport map[string]bool) {
+	for _, g := range groups {
+		writeRoutes(g.Routes, s, packagesToImport)
 
-			s.WriteString(fmt.Sprintf("%s=%s", key, getStructAndPackageName(object)))
-			if i == len(data.OverrideStructFields)-1 {
-				s.WriteString("}")
-			} else {
-				s.WriteString(",")
-			}
-			i++
+		if g.Groups != nil {
+			writeGroup(g.Groups, s, packagesToImport)
 		}
 	}
 }
 
-func getStructAndPackageName(body any) string {
-	isPointer := reflect.TypeOf(body).Kind() == reflect.Pointer
-	if isPointer {
-		body = reflect.ValueOf(body).Elem().Interface()
+// sortedKeys returns the keys of m in ascending order. Every map that ends
+// up in the generated output goes through it to keep goswag.go byte-stable.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
 
-	return reflect.TypeOf(body).String()
+	sort.Strings(keys)
+
+	return keys
 }
 
 func addTextIfNotEmptyOrDefault(s *strings.Builder, defaultText, format string, text ...string) {
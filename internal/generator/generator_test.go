@@ -1,47 +1,21 @@
 package generator
 
 import (
+	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/diegoclair/goswag/internal/generator/testutil"
+	billingdto "github.com/diegoclair/goswag/internal/generator/testutil/billing/dto"
+	identitydto "github.com/diegoclair/goswag/internal/generator/testutil/identity/dto"
 	"github.com/diegoclair/goswag/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func TestGetStructAndPackageName(t *testing.T) {
-	type args struct {
-		body any
-	}
-	tests := []struct {
-		name string
-		args args
-		want string
-	}{
-		{
-			name: "Should return the struct name and package name",
-			args: args{
-				body: models.ReturnType{},
-			},
-			want: "models.ReturnType",
-		},
-		{
-			name: "Should not return * if the struct is a pointer",
-			args: args{
-				body: &models.ReturnType{},
-			},
-			want: "models.ReturnType",
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := getStructAndPackageName(tt.args.body)
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
 func TestAddLineIfNotEmpty(t *testing.T) {
 	var tests = []struct {
 		name     string
@@ -190,7 +164,7 @@ func TestWriteGroup(t *testing.T) {
 			name: "Should return string with the group name",
 			groups: []Group{
 				{
-					GroupName: "test",
+					GroupName: "/test",
 					Routes: []Route{
 						{
 							Description: "test group",
@@ -206,7 +180,7 @@ func TestWriteGroup(t *testing.T) {
 			name: "Should recursively return string with the group name",
 			groups: []Group{
 				{
-					GroupName: "test",
+					GroupName: "/test",
 					Routes: []Route{
 						{
 							Path:        "/test",
@@ -215,7 +189,7 @@ func TestWriteGroup(t *testing.T) {
 					},
 					Groups: []Group{
 						{
-							GroupName: "test2",
+							GroupName: "/test2",
 							Routes: []Route{
 								{
 									Path:        "/test2",
@@ -226,15 +200,17 @@ func TestWriteGroup(t *testing.T) {
 					},
 				},
 			},
-			expectedStringBuilder: "// @Description test group\n// @Tags test\n// @Router /test []\n\n// @Description test group 2\n// @Tags test2\n// @Router /test2 []\n\n",
+			expectedStringBuilder: "// @Description test group\n// @Tags test\n// @Router /test []\n\n// @Description test group 2\n// @Tags test/test2\n// @Router /test2 []\n\n",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
+			_, groups := applyTags(nil, tt.groups, nil, nil)
+
 			var b strings.Builder
-			writeGroup(tt.groups, &b, map[string]bool{})
+			writeGroup(groups, &b, map[string]bool{})
 
 			assert.Equal(t, tt.expectedStringBuilder, b.String())
 		})
@@ -250,15 +226,14 @@ func TestWriteRoutes(t *testing.T) {
 	}{
 		{
 			name:      "Should group name as tag of route",
-			groupName: "test",
+			groupName: "/users",
 			routes: []Route{
 				{},
 			},
-			expectedStringBuilder: "// @Tags test\n\n",
+			expectedStringBuilder: "// @Tags users\n\n",
 		},
 		{
-			name:      "Should add summary and description if we have summary",
-			groupName: "",
+			name: "Should add summary and description if we have summary",
 			routes: []Route{
 				{
 					Summary: "test",
@@ -267,8 +242,7 @@ func TestWriteRoutes(t *testing.T) {
 			expectedStringBuilder: "// @Summary test\n// @Description test\n\n",
 		},
 		{
-			name:      "Should add description if we have description",
-			groupName: "",
+			name: "Should add description if we have description",
 			routes: []Route{
 				{
 					Description: "test",
@@ -277,8 +251,7 @@ func TestWriteRoutes(t *testing.T) {
 			expectedStringBuilder: "// @Description test\n\n",
 		},
 		{
-			name:      "Should add tags if we have tags",
-			groupName: "",
+			name: "Should add tags if we have tags",
 			routes: []Route{
 				{
 					Tags: []string{"test"},
@@ -288,7 +261,7 @@ func TestWriteRoutes(t *testing.T) {
 		},
 		{
 			name:      "Should add tags, instead of group if we have tags",
-			groupName: "group_test",
+			groupName: "/group_test",
 			routes: []Route{
 				{
 					Tags: []string{"tag_test"},
@@ -297,8 +270,7 @@ func TestWriteRoutes(t *testing.T) {
 			expectedStringBuilder: "// @Tags tag_test\n\n",
 		},
 		{
-			name:      "Should add default accept json if we have post method",
-			groupName: "",
+			name: "Should add default accept json if we have post method",
 			routes: []Route{
 				{
 					Method: "POST",
@@ -307,8 +279,7 @@ func TestWriteRoutes(t *testing.T) {
 			expectedStringBuilder: "// @Accept json\n\n",
 		},
 		{
-			name:      "Should add accept text instead of default json",
-			groupName: "",
+			name: "Should add accept text instead of default json",
 			routes: []Route{
 				{
 					Method:  "POST",
@@ -318,8 +289,7 @@ func TestWriteRoutes(t *testing.T) {
 			expectedStringBuilder: "// @Accept text\n\n",
 		},
 		{
-			name:      "Should add produces if we have return",
-			groupName: "",
+			name: "Should add produces if we have return",
 			routes: []Route{
 				{
 					Returns: []models.ReturnType{
@@ -330,8 +300,7 @@ func TestWriteRoutes(t *testing.T) {
 			expectedStringBuilder: "// @Produce json\n\n",
 		},
 		{
-			name:      "Should add request body if we have reads",
-			groupName: "",
+			name: "Should add request body if we have reads",
 			routes: []Route{
 				{
 					Reads: models.ReturnType{},
@@ -340,8 +309,79 @@ func TestWriteRoutes(t *testing.T) {
 			expectedStringBuilder: "// @Param request body models.ReturnType true \"Request\"\n\n",
 		},
 		{
-			name:      "Should add path params if we have path params",
-			groupName: "",
+			name: "Should add array request body if we have slice reads",
+			routes: []Route{
+				{
+					Reads: []models.ReturnType{},
+				},
+			},
+			expectedStringBuilder: "// @Param request body []models.ReturnType true \"Request\"\n\n",
+		},
+		{
+			name: "Should add generic request body if we have generic reads",
+			routes: []Route{
+				{
+					Reads: &testutil.StructGeneric[[]testutil.TestGeneric]{},
+				},
+			},
+			expectedStringBuilder: "// @Param request body testutil.StructGeneric[[]testutil.TestGeneric] true \"Request\"\n\n",
+		},
+		{
+			name: "Should add form params and accept multipart if we have file params",
+			routes: []Route{
+				{
+					Method: "PATCH",
+					FormParams: []Param{
+						{Name: "name", Description: "Name", ParamType: "string", Required: true},
+						{Name: "avatar", Description: "Avatar", ParamType: FileParamType},
+					},
+				},
+			},
+			expectedStringBuilder: "// @Accept mpfd\n" +
+				"// @Param name formData string true \"Name\"\n" +
+				"// @Param avatar formData file false \"Avatar\"\n\n",
+		},
+		{
+			name: "Should write the double quotes of the param descriptions as single quotes",
+			routes: []Route{
+				{
+					QueryParams: []Param{{Name: "q", Description: `the "search" terms`, ParamType: "string"}},
+				},
+			},
+			expectedStringBuilder: "// @Param q query string false \"the 'search' terms\"\n\n",
+		},
+		{
+			name: "Should add the attributes of the params",
+			routes: []Route{
+				{
+					QueryParams: []Param{
+						{
+							Name: "status", Description: "Status", ParamType: ArrayParamType,
+							ParamAttributes: models.ParamAttributes{
+								Items: "string", CollectionFormat: "multi", Enum: []any{"open", "closed"},
+							},
+						},
+						{
+							Name: "limit", Description: "Limit", ParamType: "int",
+							ParamAttributes: models.ParamAttributes{
+								Default: 20, Minimum: ptr(1.0), Maximum: ptr(100.5), Example: 10,
+							},
+						},
+						{
+							Name: "q", Description: "Search", ParamType: "string",
+							ParamAttributes: models.ParamAttributes{
+								MinLength: ptr(3), MaxLength: ptr(50), Format: "email", Pattern: "^a",
+							},
+						},
+					},
+				},
+			},
+			expectedStringBuilder: "// @Param status query []string false \"Status\" Enums(open, closed) collectionFormat(multi)\n" +
+				"// @Param limit query int false \"Limit\" default(20) minimum(1) maximum(100.5) example(10)\n" +
+				"// @Param q query string false \"Search\" minLength(3) maxLength(50) format(email)\n\n",
+		},
+		{
+			name: "Should add path params if we have path params",
 			routes: []Route{
 				{
 					PathParams: []Param{
@@ -357,8 +397,7 @@ func TestWriteRoutes(t *testing.T) {
 			expectedStringBuilder: "// @Param test path string true \"someTest\"\n\n",
 		},
 		{
-			name:      "Should add query params if we have query params",
-			groupName: "",
+			name: "Should add query params if we have query params",
 			routes: []Route{
 				{
 					QueryParams: []Param{
@@ -374,8 +413,7 @@ func TestWriteRoutes(t *testing.T) {
 			expectedStringBuilder: "// @Param test query string true \"test\"\n\n",
 		},
 		{
-			name:      "Should add header params if we have header params",
-			groupName: "",
+			name: "Should add header params if we have header params",
 			routes: []Route{
 				{
 					HeaderParams: []Param{
@@ -391,8 +429,7 @@ func TestWriteRoutes(t *testing.T) {
 			expectedStringBuilder: "// @Param test header string true \"test\"\n\n",
 		},
 		{
-			name:      "Should add router if we have path",
-			groupName: "",
+			name: "Should add router if we have path",
 			routes: []Route{
 				{
 					Path:   "/test",
@@ -402,8 +439,7 @@ func TestWriteRoutes(t *testing.T) {
 			expectedStringBuilder: "// @Router /test [get]\n\n",
 		},
 		{
-			name:      "Should add func name if we have func name",
-			groupName: "",
+			name: "Should add func name if we have func name",
 			routes: []Route{
 				{
 					FuncName: "test",
@@ -416,8 +452,14 @@ func TestWriteRoutes(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
+			routes := tt.routes
+			if tt.groupName != "" {
+				_, groups := applyTags(nil, []Group{{GroupName: tt.groupName, Routes: routes}}, nil, nil)
+				routes = groups[0].Routes
+			}
+
 			var b strings.Builder
-			writeRoutes(tt.groupName, tt.routes, &b, map[string]bool{})
+			writeRoutes(routes, &b, map[string]bool{})
 
 			assert.Equal(t, tt.expectedStringBuilder, b.String())
 		})
@@ -473,6 +515,129 @@ func TestWriteReturns(t *testing.T) {
 			expectedStringBuilder: "// @Failure 400\n",
 			expectedPackages:      map[string]bool{},
 		},
+		{
+			name: "Should write informational and redirect codes as success",
+			returns: []models.ReturnType{
+				{StatusCode: 101},
+				{StatusCode: 301},
+				{StatusCode: 304},
+			},
+			expectedStringBuilder: "// @Success 101\n// @Success 301\n// @Success 304\n",
+			expectedPackages:      map[string]bool{},
+		},
+		{
+			name: "Should write the default response as failure",
+			returns: []models.ReturnType{
+				{
+					Default:     true,
+					Body:        models.ReturnType{},
+					Description: "Unexpected error",
+					Headers:     []models.ResponseHeader{{Name: "X-Request-Id", Type: "string"}},
+				},
+			},
+			expectedStringBuilder: "// @Failure default {object} models.ReturnType \"Unexpected error\"\n" +
+				"// @Header default {string} X-Request-Id \"\"\n",
+			expectedPackages: map[string]bool{"github.com/diegoclair/goswag/models": true},
+		},
+		{
+			name: "Should write the description and headers of the response",
+			returns: []models.ReturnType{
+				{
+					StatusCode:  201,
+					Body:        models.ReturnType{},
+					Description: "Created",
+					Headers: []models.ResponseHeader{
+						{Name: "Location", Type: "string", Description: "URL of the user"},
+						{Name: "X-RateLimit-Remaining", Type: "int"},
+					},
+				},
+				{
+					StatusCode:  429,
+					Description: "Too many requests",
+					Headers:     []models.ResponseHeader{{Name: "Retry-After", Type: "int", Description: "Seconds to wait"}},
+				},
+			},
+			expectedStringBuilder: "// @Success 201 {object} models.ReturnType \"Created\"\n" +
+				"// @Header 201 {string} Location \"URL of the user\"\n" +
+				"// @Header 201 {integer} X-RateLimit-Remaining \"\"\n" +
+				"// @Failure 429 \"Too many requests\"\n" +
+				"// @Header 429 {integer} Retry-After \"Seconds to wait\"\n",
+			expectedPackages: map[string]bool{"github.com/diegoclair/goswag/models": true},
+		},
+		{
+			name: "Should write the double quotes of the descriptions as single quotes",
+			returns: []models.ReturnType{
+				{
+					StatusCode:  201,
+					Description: `the "created" user`,
+					Headers:     []models.ResponseHeader{{Name: "Location", Type: "string", Description: `the "self" link`}},
+				},
+			},
+			expectedStringBuilder: "// @Success 201 \"the 'created' user\"\n" +
+				"// @Header 201 {string} Location \"the 'self' link\"\n",
+			expectedPackages: map[string]bool{},
+		},
+		{
+			name: "Should write slices as array of the element type",
+			returns: []models.ReturnType{
+				{
+					StatusCode: 200,
+					Body:       []*models.ReturnType{},
+				},
+			},
+			expectedStringBuilder: "// @Success 200 {array} models.ReturnType\n",
+			expectedPackages:      map[string]bool{"github.com/diegoclair/goswag/models": true},
+		},
+		{
+			name: "Should write primitives with their swag kind",
+			returns: []models.ReturnType{
+				{StatusCode: 200, Body: ""},
+				{StatusCode: 201, Body: int64(0)},
+				{StatusCode: 202, Body: 1.5},
+				{StatusCode: 203, Body: true},
+			},
+			expectedStringBuilder: "// @Success 200 {string} string\n" +
+				"// @Success 201 {integer} int64\n" +
+				"// @Success 202 {number} float64\n" +
+				"// @Success 203 {boolean} bool\n",
+			expectedPackages: map[string]bool{},
+		},
+		{
+			name: "Should write maps as object",
+			returns: []models.ReturnType{
+				{
+					StatusCode: 200,
+					Body:       map[string]models.ReturnType{},
+				},
+			},
+			expectedStringBuilder: "// @Success 200 {object} map[string]models.ReturnType\n",
+			expectedPackages:      map[string]bool{"github.com/diegoclair/goswag/models": true},
+		},
+		{
+			name: "Should write bytes as file",
+			returns: []models.ReturnType{
+				{
+					StatusCode: 200,
+					Body:       []byte{},
+				},
+			},
+			expectedStringBuilder: "// @Success 200 {file} file\n",
+			expectedPackages:      map[string]bool{},
+		},
+		{
+			name: "Should write every type argument of a generic body and import their packages",
+			returns: []models.ReturnType{
+				{
+					StatusCode: 200,
+					Body:       testutil.Envelope[testutil.Page[models.ReturnType, testutil.Cursor]]{},
+				},
+			},
+			expectedStringBuilder: "// @Success 200 {object} testutil.Envelope[testutil.Page[models.ReturnType,testutil.Cursor]]\n",
+			expectedPackages: map[string]bool{
+				"github.com/diegoclair/goswag/internal/generator/testutil": true,
+				"github.com/diegoclair/goswag/models":                      true,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -491,7 +656,7 @@ func TestWriteReturns(t *testing.T) {
 	}
 }
 
-func Test_handleOverrideStructFields(t *testing.T) {
+func Test_writeOverrides(t *testing.T) {
 	var tests = []struct {
 		name                  string
 		data                  models.ReturnType
@@ -523,13 +688,47 @@ func Test_handleOverrideStructFields(t *testing.T) {
 			},
 			expectedStringBuilder: "{test=testutil.TestGeneric,test2=testutil.TestGeneric}",
 		},
+		{
+			name: "Should add override struct fields sorted by key",
+			data: models.ReturnType{
+				Body: testutil.OverrideStruct{},
+				OverrideStructFields: map[string]any{
+					"meta": testutil.TestGeneric{},
+					"data": testutil.OverrideStruct{},
+					"body": testutil.TestGeneric{},
+				},
+			},
+			expectedStringBuilder: "{body=testutil.TestGeneric,data=testutil.OverrideStruct,meta=testutil.TestGeneric}",
+		},
+		{
+			name: "Should nest dotted overrides in the type of the field",
+			data: models.ReturnType{
+				Body: testutil.Envelope[testutil.Page[any, testutil.Cursor]]{},
+				OverrideStructFields: map[string]any{
+					"Data.Items": []testutil.TestGeneric{},
+				},
+			},
+			expectedStringBuilder: "{Data=testutil.Page[interface{},testutil.Cursor]{Items=[]testutil.TestGeneric}}",
+		},
+		{
+			name: "Should nest dotted overrides in the type of an overridden field",
+			data: models.ReturnType{
+				Body: testutil.OverrideStruct{},
+				OverrideStructFields: map[string]any{
+					"body":           testutil.OverrideStruct{},
+					"body.body":      testutil.Envelope[any]{},
+					"body.body.Data": testutil.TestGeneric{},
+				},
+			},
+			expectedStringBuilder: "{body=testutil.OverrideStruct{body=testutil.Envelope[interface{}]{Data=testutil.TestGeneric}}}",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
 			var b strings.Builder
-			handleOverrideStructFields(&b, tt.data)
+			writeOverrides(&b, tt.data.Body, tt.data.OverrideStructFields, make(map[string]bool))
 
 			assert.Equal(t, tt.expectedStringBuilder, b.String())
 		})
@@ -539,6 +738,7 @@ func Test_handleOverrideStructFields(t *testing.T) {
 func Test_writeFileContent(t *testing.T) {
 	type args struct {
 		file             io.Writer
+		packageName      string
 		content          string
 		packagesToImport map[string]bool
 	}
@@ -551,21 +751,47 @@ func Test_writeFileContent(t *testing.T) {
 			name: "Should write the file content",
 			args: args{
 				file:             &strings.Builder{},
+				packageName:      "main",
 				content:          "test",
 				packagesToImport: map[string]bool{"test": true},
 			},
-			expected: "package main\n\nimport (\n\t_ \"test\"\n)\n\ntest",
+			expected: "// Code generated by goswag. DO NOT EDIT.\n\npackage main\n\nimport (\n\t_ \"test\"\n)\n\ntest",
+		},
+		{
+			name: "Should write the imports sorted",
+			args: args{
+				file:        &strings.Builder{},
+				packageName: "main",
+				content:     "test",
+				packagesToImport: map[string]bool{
+					"github.com/c/pkg": true,
+					"github.com/a/pkg": true,
+					"github.com/b/pkg": true,
+				},
+			},
+			expected: "// Code generated by goswag. DO NOT EDIT.\n\npackage main\n\nimport (\n\t_ \"github.com/a/pkg\"\n\t_ \"github.com/b/pkg\"\n\t_ \"github.com/c/pkg\"\n)\n\ntest",
+		},
+		{
+			name: "Should write the given package name and no import block without packages",
+			args: args{
+				file:             &strings.Builder{},
+				packageName:      "docs",
+				content:          "test",
+				packagesToImport: map[string]bool{},
+			},
+			expected: "// Code generated by goswag. DO NOT EDIT.\n\npackage docs\n\ntest",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			writeFileContent(tt.args.file, tt.args.content, tt.args.packagesToImport)
+			writeFileContent(tt.args.file, tt.args.packageName, tt.args.content, tt.args.packagesToImport, nil)
+			assert.Equal(t, tt.expected, tt.args.file.(*strings.Builder).String())
 		})
 	}
 }
 
-func Test_addDefaultResponses(t *testing.T) {
+func Test_applyDefaults_responses(t *testing.T) {
 	type args struct {
 		routes           []Route
 		groups           []Group
@@ -657,85 +883,99 @@ func Test_addDefaultResponses(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotRoutes, gotGroups := addDefaultResponses(tt.args.routes, tt.args.groups, tt.args.defaultResponses)
+			gotRoutes, gotGroups := applyDefaults(tt.args.routes, tt.args.groups, RouteDefaults{Responses: tt.args.defaultResponses})
 			assert.Equal(t, tt.expected, gotRoutes)
 			assert.Equal(t, tt.expected, gotGroups[0].Routes)
 		})
 	}
 }
 
-func Test_addPackageToImport(t *testing.T) {
-	tests := []struct {
-		name         string
-		data         models.ReturnType
-		initialPkgs  map[string]bool
-		expectedPkgs map[string]bool
-	}{
-		{
-			name: "Should add package for non-generic type",
-			data: models.ReturnType{
-				Body: models.ReturnType{},
-			},
-			initialPkgs: make(map[string]bool),
-			expectedPkgs: map[string]bool{
-				"github.com/diegoclair/goswag/models": true,
-			},
-		},
-		{
-			name: "Should add package for generic type",
-			data: models.ReturnType{
-				Body: testutil.StructGeneric[testutil.TestGeneric]{},
-			},
-			initialPkgs: make(map[string]bool),
-			expectedPkgs: map[string]bool{
-				"github.com/diegoclair/goswag/internal/generator/testutil": true,
-			},
-		},
-		{
-			name: "Should not add package for primitive type",
-			data: models.ReturnType{
-				Body: 42,
-			},
-			initialPkgs:  make(map[string]bool),
-			expectedPkgs: map[string]bool{},
-		},
-		{
-			name: "Should not add package for nil body",
-			data: models.ReturnType{
-				Body: nil,
-			},
-			initialPkgs:  make(map[string]bool),
-			expectedPkgs: map[string]bool{},
-		},
-		{
-			name: "Should not duplicate existing package",
-			data: models.ReturnType{
-				Body: models.ReturnType{},
-			},
-			initialPkgs: map[string]bool{
-				"github.com/diegoclair/goswag/models": true,
-			},
-			expectedPkgs: map[string]bool{
-				"github.com/diegoclair/goswag/models": true,
-			},
-		},
-		{
-			name: "Should add package for pointer to struct",
-			data: models.ReturnType{
-				Body: &models.ReturnType{},
-			},
-			initialPkgs: make(map[string]bool),
-			expectedPkgs: map[string]bool{
-				"github.com/diegoclair/goswag/models": true,
-			},
+type testLogger struct {
+	lines []string
+}
+
+func (l *testLogger) Printf(format string, v ...any) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestGenerate(t *testing.T) {
+	doc := Doc{
+		Routes: []Route{
+			{Path: "/test", Method: "GET", FuncName: "handleTest"},
 		},
+		DefaultResponses: []models.ReturnType{{StatusCode: 500}},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			packagesToImport := tt.initialPkgs
-			addPackageToImport(tt.data, packagesToImport)
-			assert.Equal(t, tt.expectedPkgs, packagesToImport)
-		})
-	}
+	t.Run("Should write the stub with the given options", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "nested", "docs")
+		logger := &testLogger{}
+
+		err := Generate(doc,
+			WithOutputDir(dir),
+			WithFileName("stub.go"),
+			WithPackageName("docs"),
+			WithLogger(logger),
+		)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(dir, "stub.go"))
+		require.NoError(t, err)
+		assert.Equal(t, "// Code generated by goswag. DO NOT EDIT.\n\npackage docs\n\n"+
+			"// @ID handleTest\n// @Produce json\n// @Failure 500\n// @Router /test [get]\nfunc handleTest() {} //nolint:unused \n\n", string(content))
+		assert.Len(t, logger.lines, 2)
+	})
+
+	t.Run("Should return an error instead of exiting when the file can't be written", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, os.WriteFile(file, nil, 0o644))
+
+		err := Generate(doc, WithOutputDir(file), WithLogger(&testLogger{}))
+		assert.Error(t, err)
+	})
+
+	t.Run("Should not mutate the doc so it can be rendered twice", func(t *testing.T) {
+		var first, second strings.Builder
+
+		_, err := Write(&first, doc)
+		require.NoError(t, err)
+		_, err = Write(&second, doc)
+		require.NoError(t, err)
+
+		assert.Equal(t, first.String(), second.String())
+		assert.Nil(t, doc.Routes[0].Returns)
+	})
+}
+
+func TestWrite(t *testing.T) {
+	var b strings.Builder
+
+	n, err := Write(&b, Doc{Routes: []Route{{Path: "/test", Method: "GET"}}}, WithPackageName("api"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "// Code generated by goswag. DO NOT EDIT.\n\npackage api\n\n// @Router /test [get]\n\n", b.String())
+	assert.Equal(t, int64(b.Len()), n)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestWrite_samePackageName(t *testing.T) {
+	var b strings.Builder
+
+	_, err := Write(&b, Doc{Routes: []Route{{
+		Path:    "/users",
+		Method:  "GET",
+		Reads:   identitydto.User{},
+		Returns: []models.ReturnType{{StatusCode: 200, Body: billingdto.Page[identitydto.User]{}}},
+	}}}, WithPackageName("api"))
+	require.NoError(t, err)
+
+	out := b.String()
+	assert.Contains(t, out, "\tbilling_dto \""+testutilPkg+"/billing/dto\"\n")
+	assert.Contains(t, out, "\tidentity_dto \""+testutilPkg+"/identity/dto\"\n")
+	assert.Contains(t, out, "var _ *billing_dto.Page[identity_dto.User]\n")
+	assert.Contains(t, out, "var _ *identity_dto.User\n")
+	assert.Contains(t, out, "// @Param request body identity_dto.User true \"Request\"\n")
+	assert.Contains(t, out, "// @Success 200 {object} billing_dto.Page[identity_dto.User]\n")
 }
@@ -0,0 +1,122 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/diegoclair/goswag/models"
+)
+
+// writeInfo writes the swag general API info of the API. Like the security
+// definitions, `goswag docs` passes it to swag together with the general
+// info of main.go, and it takes precedence over it.
+func writeInfo(s *strings.Builder, info models.Info) {
+	lines := &strings.Builder{}
+
+	addLineIfNotEmpty(lines, info.Title, "// @title %s\n")
+	addLineIfNotEmpty(lines, info.Version, "// @version %s\n")
+	if info.Description != "" {
+		// swag joins consecutive @description lines
+		for _, line := range strings.Split(info.Description, "\n") {
+			lines.WriteString(fmt.Sprintf("// @description %s\n", line))
+		}
+	}
+	addLineIfNotEmpty(lines, info.TermsOfService, "// @termsOfService %s\n")
+	addLineIfNotEmpty(lines, info.Contact.Name, "// @contact.name %s\n")
+	addLineIfNotEmpty(lines, info.Contact.URL, "// @contact.url %s\n")
+	addLineIfNotEmpty(lines, info.Contact.Email, "// @contact.email %s\n")
+	addLineIfNotEmpty(lines, info.License.Name, "// @license.name %s\n")
+	addLineIfNotEmpty(lines, info.License.URL, "// @license.url %s\n")
+	addLineIfNotEmpty(lines, info.Host, "// @host %s\n")
+	addLineIfNotEmpty(lines, info.BasePath, "// @BasePath %s\n")
+	addLineIfNotEmpty(lines, strings.Join(info.Schemes, " "), "// @schemes %s\n")
+	addLineIfNotEmpty(lines, info.ExternalDocs.Description, "// @externalDocs.description %s\n")
+	addLineIfNotEmpty(lines, info.ExternalDocs.URL, "// @externalDocs.url %s\n")
+
+	if lines.Len() == 0 {
+		return
+	}
+
+	s.WriteString(lines.String())
+	s.WriteString("\n")
+}
+
+type OpenAPIContact struct {
+	Name  string `json:"name,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+type OpenAPILicense struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+type ExternalDocs struct {
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
+}
+
+// openAPIInfo converts the general info of the API to OpenAPI, keeping the
+// "API" title and "1.0" version required by the spec when they are not set.
+func openAPIInfo(info models.Info) OpenAPIInfo {
+	oi := OpenAPIInfo{
+		Title:          info.Title,
+		Version:        info.Version,
+		Description:    info.Description,
+		TermsOfService: info.TermsOfService,
+	}
+
+	if oi.Title == "" {
+		oi.Title = "API"
+	}
+	if oi.Version == "" {
+		oi.Version = "1.0"
+	}
+
+	if info.Contact != (models.Contact{}) {
+		oi.Contact = &OpenAPIContact{Name: info.Contact.Name, URL: info.Contact.URL, Email: info.Contact.Email}
+	}
+	if info.License.Name != "" {
+		oi.License = &OpenAPILicense{Name: info.License.Name, URL: info.License.URL}
+	}
+
+	return oi
+}
+
+// openAPIServers builds the servers of the API from its host, base path and
+// schemes, the swagger 2.0 way of describing them. Without schemes the URL
+// is scheme relative.
+func openAPIServers(info models.Info) []Server {
+	if info.Host == "" {
+		if info.BasePath == "" {
+			return nil
+		}
+
+		return []Server{{URL: info.BasePath}}
+	}
+
+	if len(info.Schemes) == 0 {
+		return []Server{{URL: "//" + info.Host + info.BasePath}}
+	}
+
+	servers := make([]Server, 0, len(info.Schemes))
+	for _, scheme := range info.Schemes {
+		servers = append(servers, Server{URL: scheme + "://" + info.Host + info.BasePath})
+	}
+
+	return servers
+}
+
+// openAPIExternalDocs converts external docs to OpenAPI, nil when they have no URL.
+func openAPIExternalDocs(docs models.ExternalDocs) *ExternalDocs {
+	if docs.URL == "" {
+		return nil
+	}
+
+	return &ExternalDocs{Description: docs.Description, URL: docs.URL}
+}
@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/diegoclair/goswag/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteInfo(t *testing.T) {
+	tests := []struct {
+		name string
+		info models.Info
+		want string
+	}{
+		{
+			name: "Should write nothing without info",
+		},
+		{
+			name: "Should write every field of the info",
+			info: models.Info{
+				Title:          "Users API",
+				Version:        "v1.2.3",
+				Description:    "Manages the users.\nAnd their roles.",
+				TermsOfService: "https://example.com/terms",
+				Contact:        models.Contact{Name: "Team", URL: "https://example.com", Email: "team@example.com"},
+				License:        models.License{Name: "MIT", URL: "https://opensource.org/licenses/MIT"},
+				Host:           "api.example.com",
+				BasePath:       "/v1",
+				Schemes:        []string{"https", "http"},
+				ExternalDocs:   models.ExternalDocs{Description: "Guides", URL: "https://example.com/docs"},
+			},
+			want: "// @title Users API\n" +
+				"// @version v1.2.3\n" +
+				"// @description Manages the users.\n" +
+				"// @description And their roles.\n" +
+				"// @termsOfService https://example.com/terms\n" +
+				"// @contact.name Team\n" +
+				"// @contact.url https://example.com\n" +
+				"// @contact.email team@example.com\n" +
+				"// @license.name MIT\n" +
+				"// @license.url https://opensource.org/licenses/MIT\n" +
+				"// @host api.example.com\n" +
+				"// @BasePath /v1\n" +
+				"// @schemes https http\n" +
+				"// @externalDocs.description Guides\n" +
+				"// @externalDocs.url https://example.com/docs\n" +
+				"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &strings.Builder{}
+			writeInfo(s, tt.info)
+			assert.Equal(t, tt.want, s.String())
+		})
+	}
+}
+
+func TestOpenAPIServers(t *testing.T) {
+	tests := []struct {
+		name string
+		info models.Info
+		want []Server
+	}{
+		{
+			name: "Should return no servers without host and base path",
+		},
+		{
+			name: "Should return the base path without host",
+			info: models.Info{BasePath: "/v1"},
+			want: []Server{{URL: "/v1"}},
+		},
+		{
+			name: "Should return a scheme relative URL without schemes",
+			info: models.Info{Host: "api.example.com", BasePath: "/v1"},
+			want: []Server{{URL: "//api.example.com/v1"}},
+		},
+		{
+			name: "Should return a server per scheme",
+			info: models.Info{Host: "api.example.com", Schemes: []string{"https", "http"}},
+			want: []Server{{URL: "https://api.example.com"}, {URL: "http://api.example.com"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, openAPIServers(tt.info))
+		})
+	}
+}
+
+func TestBuildOpenAPI_info(t *testing.T) {
+	t.Run("Should keep the required title and version when the info is not set", func(t *testing.T) {
+		doc := BuildOpenAPI(Doc{})
+		assert.Equal(t, OpenAPIInfo{Title: "API", Version: "1.0"}, doc.Info)
+		assert.Nil(t, doc.Servers)
+		assert.Nil(t, doc.ExternalDocs)
+	})
+
+	t.Run("Should convert the info", func(t *testing.T) {
+		doc := BuildOpenAPI(Doc{Info: models.Info{
+			Title:        "Users API",
+			Version:      "v1.2.3",
+			Contact:      models.Contact{Email: "team@example.com"},
+			License:      models.License{Name: "MIT"},
+			Host:         "api.example.com",
+			Schemes:      []string{"https"},
+			ExternalDocs: models.ExternalDocs{URL: "https://example.com/docs"},
+		}})
+
+		assert.Equal(t, OpenAPIInfo{
+			Title:   "Users API",
+			Version: "v1.2.3",
+			Contact: &OpenAPIContact{Email: "team@example.com"},
+			License: &OpenAPILicense{Name: "MIT"},
+		}, doc.Info)
+		assert.Equal(t, []Server{{URL: "https://api.example.com"}}, doc.Servers)
+		assert.Equal(t, &ExternalDocs{URL: "https://example.com/docs"}, doc.ExternalDocs)
+	})
+}
@@ -0,0 +1,210 @@
+package generator
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ManifestEnv is set by `goswag routes` to the file where Generate must
+// write the route manifest instead of the stub file.
+const ManifestEnv = "GOSWAG_MANIFEST"
+
+// ManifestVersion is the version of the manifest format. It is bumped when
+// a field changes meaning or is removed, not when one is added.
+const ManifestVersion = 1
+
+// Manifest is the machine-readable list of the documented routes, for the
+// tools that need the routes without parsing the stub or the spec.
+type Manifest struct {
+	Version int             `json:"version"`
+	Routes  []ManifestRoute `json:"routes"`
+}
+
+// ManifestRoute is a documented route, as the stub documents it: with the
+// default responses, the inherited tags and the default operation id.
+type ManifestRoute struct {
+	Method      string             `json:"method"`
+	Path        string             `json:"path"`
+	Handler     string             `json:"handler,omitempty"`
+	OperationID string             `json:"operationId,omitempty"`
+	Summary     string             `json:"summary,omitempty"`
+	Tags        []string           `json:"tags,omitempty"`
+	Spec        string             `json:"spec,omitempty"` // "" for the default spec
+	Deprecated  bool               `json:"deprecated,omitempty"`
+	Params      []ManifestParam    `json:"params,omitempty"`
+	Body        string             `json:"body,omitempty"` // the type of the request body
+	Responses   []ManifestResponse `json:"responses,omitempty"`
+	// Source is where the route is registered, file:line, relative to the
+	// root of the module of the file.
+	Source string `json:"source,omitempty"`
+}
+
+// ManifestParam is a path, query, header or formData param of a route.
+type ManifestParam struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Type     string `json:"type"`
+	Required bool   `json:"required,omitempty"`
+}
+
+// ManifestResponse is a response of a route. Status is its status code, or
+// "default" for the catch-all response.
+type ManifestResponse struct {
+	Status      string `json:"status"`
+	Body        string `json:"body,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// BuildManifest returns the manifest of the routes of doc that are not
+// hidden, spec by spec, in registration order.
+func BuildManifest(doc Doc) Manifest {
+	manifest := Manifest{Version: ManifestVersion, Routes: []ManifestRoute{}}
+	roots := make(map[string]string)
+
+	for _, spec := range Specs(doc) {
+		routes, groups := prepareRoutes(spec.Doc)
+		manifest.Routes = appendManifestRoutes(manifest.Routes, routes, spec.Name, roots)
+		manifest.Routes = appendManifestGroups(manifest.Routes, groups, spec.Name, roots)
+	}
+
+	return manifest
+}
+
+// WriteManifest writes the manifest of doc to w, as indented JSON.
+func WriteManifest(w io.Writer, doc Doc) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(BuildManifest(doc))
+}
+
+// writeManifestFile writes the manifest of doc to the file at path.
+func writeManifestFile(path string, doc Doc) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating the manifest: %w", err)
+	}
+
+	if err := WriteManifest(f, doc); err != nil {
+		f.Close()
+		return fmt.Errorf("writing the manifest: %w", err)
+	}
+
+	return f.Close()
+}
+
+func appendManifestGroups(dst []ManifestRoute, groups []Group, spec string, roots map[string]string) []ManifestRoute {
+	for _, g := range groups {
+		dst = appendManifestRoutes(dst, g.Routes, spec, roots)
+		dst = appendManifestGroups(dst, g.Groups, spec, roots)
+	}
+
+	return dst
+}
+
+func appendManifestRoutes(dst []ManifestRoute, routes []Route, spec string, roots map[string]string) []ManifestRoute {
+	for _, r := range routes {
+		mr := ManifestRoute{
+			Method:      r.Method,
+			Path:        r.Path,
+			Handler:     r.Handler,
+			OperationID: r.OperationID,
+			Summary:     r.Summary,
+			Tags:        r.Tags,
+			Spec:        spec,
+			Deprecated:  r.Deprecated,
+			Source:      relativeSource(r.Source, roots),
+		}
+
+		mr.Params = appendManifestParams(mr.Params, "path", r.PathParams)
+		mr.Params = appendManifestParams(mr.Params, "query", r.QueryParams)
+		mr.Params = appendManifestParams(mr.Params, "header", r.HeaderParams)
+		mr.Params = appendManifestParams(mr.Params, "formData", r.FormParams)
+
+		if r.Reads != nil {
+			mr.Body = bodyTypeName(r.Reads, nil)
+		}
+
+		for _, ret := range r.Returns {
+			code := responseCode(ret)
+			if code == "" {
+				continue
+			}
+
+			resp := ManifestResponse{Status: code, Description: ret.Description}
+			if ret.Body != nil {
+				resp.Body = bodyTypeName(ret.Body, nil)
+			}
+
+			mr.Responses = append(mr.Responses, resp)
+		}
+
+		dst = append(dst, mr)
+	}
+
+	return dst
+}
+
+func appendManifestParams(dst []ManifestParam, in string, params []Param) []ManifestParam {
+	for _, p := range params {
+		paramType := p.ParamType
+		if paramType == ArrayParamType {
+			paramType = "[]" + cmp.Or(p.Items, "string")
+		}
+
+		dst = append(dst, ManifestParam{Name: p.Name, In: in, Type: paramType, Required: p.Required})
+	}
+
+	return dst
+}
+
+// relativeSource makes the file of a file:line source relative to the root
+// of its module, the closest folder with a go.mod, so the manifest doesn't
+// depend on where the module is checked out. roots caches the root of each
+// folder; sources out of any module are kept as they are.
+func relativeSource(source string, roots map[string]string) string {
+	i := strings.LastIndex(source, ":")
+	if i < 0 || !filepath.IsAbs(source[:i]) {
+		return source
+	}
+	file, line := source[:i], source[i:]
+
+	dir := filepath.Dir(file)
+	root, ok := roots[dir]
+	if !ok {
+		root = moduleRoot(dir)
+		roots[dir] = root
+	}
+
+	if root == "" {
+		return source
+	}
+
+	rel, err := filepath.Rel(root, file)
+	if err != nil {
+		return source
+	}
+
+	return filepath.ToSlash(rel) + line
+}
+
+// moduleRoot returns the closest folder of dir, or dir itself, with a
+// go.mod, or "" when there is none.
+func moduleRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,167 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/diegoclair/goswag/internal/generator/testutil"
+	"github.com/diegoclair/goswag/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var manifestDoc = Doc{
+	Routes: []Route{
+		{
+			Method:     "GET",
+			Path:       "/users/:id",
+			FuncName:   "getUser_1a2b3c4d",
+			Handler:    "github.com/foo/api.(*Handler).getUser",
+			Source:     "relative/routes.go:12",
+			Summary:    "Get a user",
+			PathParams: []Param{{Name: "id", ParamType: "int", Required: true}},
+			QueryParams: []Param{
+				{Name: "fields", ParamType: ArrayParamType},
+			},
+			Returns: []models.ReturnType{
+				{StatusCode: 200, Body: testutil.TestGeneric{}},
+				{StatusCode: 404, Description: "User not found"},
+			},
+		},
+		{Method: "GET", Path: "/internal", Hidden: true},
+	},
+	Groups: []Group{
+		{
+			GroupName: "/admin",
+			Routes: []Route{
+				{Method: "POST", Path: "/admin/users", Reads: testutil.TestGeneric{}, Deprecated: true},
+			},
+		},
+		{
+			GroupName: "/billing",
+			Spec:      "billing",
+			Routes:    []Route{{Method: "GET", Path: "/billing/invoices", Returns: []models.ReturnType{{StatusCode: 200, Body: []testutil.TestGeneric{}}}}},
+		},
+	},
+	DefaultResponses: []models.ReturnType{{StatusCode: 500, Description: "Internal error"}},
+}
+
+func TestBuildManifest(t *testing.T) {
+	want := Manifest{
+		Version: ManifestVersion,
+		Routes: []ManifestRoute{
+			{
+				Method:      "GET",
+				Path:        "/users/:id",
+				Handler:     "github.com/foo/api.(*Handler).getUser",
+				OperationID: "getUser",
+				Summary:     "Get a user",
+				Params: []ManifestParam{
+					{Name: "id", In: "path", Type: "int", Required: true},
+					{Name: "fields", In: "query", Type: "[]string"},
+				},
+				Responses: []ManifestResponse{
+					{Status: "200", Body: "testutil.TestGeneric"},
+					{Status: "404", Description: "User not found"},
+					{Status: "500", Description: "Internal error"},
+				},
+				Source: "relative/routes.go:12",
+			},
+			{
+				Method:     "POST",
+				Path:       "/admin/users",
+				Tags:       []string{"admin"},
+				Deprecated: true,
+				Body:       "testutil.TestGeneric",
+				Responses:  []ManifestResponse{{Status: "500", Description: "Internal error"}},
+			},
+			{
+				Method: "GET",
+				Path:   "/billing/invoices",
+				Tags:   []string{"billing"},
+				Spec:   "billing",
+				Responses: []ManifestResponse{
+					{Status: "200", Body: "[]testutil.TestGeneric"},
+					{Status: "500", Description: "Internal error"},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, want, BuildManifest(manifestDoc))
+}
+
+func TestBuildManifest_noRoutes(t *testing.T) {
+	var b strings.Builder
+
+	require.NoError(t, WriteManifest(&b, Doc{}))
+
+	assert.Equal(t, "{\n  \"version\": 1,\n  \"routes\": []\n}\n", b.String(), "Should keep the routes as a list")
+}
+
+func TestRelativeSource(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\n"), 0o644))
+	require.NoError(t, os.Mkdir(filepath.Join(root, "api"), 0o755))
+
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{
+			name:   "Should make the file relative to the root of its module",
+			source: filepath.Join(root, "api", "routes.go") + ":12",
+			want:   "api/routes.go:12",
+		},
+		{
+			name:   "Should keep relative sources",
+			source: "api/routes.go:12",
+			want:   "api/routes.go:12",
+		},
+		{
+			name:   "Should keep empty sources",
+			source: "",
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, relativeSource(tt.source, make(map[string]string)))
+		})
+	}
+}
+
+func TestGenerate_manifest(t *testing.T) {
+	t.Run("Should write the manifest instead of the stub", func(t *testing.T) {
+		dir := t.TempDir()
+		var b strings.Builder
+
+		err := Generate(manifestDoc, WithOutputDir(dir), WithManifest(&b), WithLogger(&testLogger{}))
+		require.NoError(t, err)
+
+		assert.Contains(t, b.String(), `"path": "/users/:id"`)
+
+		_, err = os.Stat(filepath.Join(dir, defaultFileName))
+		assert.True(t, os.IsNotExist(err), "Should not write the stub file")
+	})
+
+	t.Run("Should write the manifest to the file of the environment", func(t *testing.T) {
+		dir := t.TempDir()
+		file := filepath.Join(dir, "manifest.json")
+		t.Setenv(ManifestEnv, file)
+
+		err := Generate(manifestDoc, WithOutputDir(dir), WithAudience("partners"), WithLogger(&testLogger{}))
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `"path": "/admin/users"`)
+
+		_, err = os.Stat(filepath.Join(dir, defaultFileName))
+		assert.True(t, os.IsNotExist(err), "Should not write the stub file")
+	})
+}
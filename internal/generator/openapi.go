@@ -0,0 +1,506 @@
+package generator
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/diegoclair/goswag/models"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	openAPIVersion  = "3.1.0"
+	openAPIJSONFile = "openapi.json"
+	openAPIYAMLFile = "openapi.yaml"
+)
+
+// OpenAPI is the root of an OpenAPI 3.1 document.
+type OpenAPI struct {
+	OpenAPI      string               `json:"openapi"`
+	Info         OpenAPIInfo          `json:"info"`
+	Servers      []Server             `json:"servers,omitempty"`
+	Paths        map[string]*PathItem `json:"paths"`
+	Components   *Components          `json:"components,omitempty"`
+	Tags         []OpenAPITag         `json:"tags,omitempty"`
+	ExternalDocs *ExternalDocs        `json:"externalDocs,omitempty"`
+}
+
+type OpenAPIInfo struct {
+	Title          string          `json:"title"`
+	Version        string          `json:"version"`
+	Description    string          `json:"description,omitempty"`
+	TermsOfService string          `json:"termsOfService,omitempty"`
+	Contact        *OpenAPIContact `json:"contact,omitempty"`
+	License        *OpenAPILicense `json:"license,omitempty"`
+}
+
+// PathItem maps a lower-case http method to its operation.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	OperationID string                `json:"operationId,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Extensions  map[string]any        `json:"-"` // inlined by MarshalJSON
+}
+
+// MarshalJSON inlines the vendor extensions next to the fields of the operation.
+func (o *Operation) MarshalJSON() ([]byte, error) {
+	type operation Operation
+
+	content, err := json.Marshal((*operation)(o))
+	if err != nil || len(o.Extensions) == 0 {
+		return content, err
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return nil, err
+	}
+
+	for key, value := range o.Extensions {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("encoding extension %s: %w", key, err)
+		}
+		fields[key] = raw
+	}
+
+	return json.Marshal(fields)
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Style       string  `json:"style,omitempty"`
+	Explode     *bool   `json:"explode,omitempty"`
+	Schema      *Schema `json:"schema"`
+	Example     any     `json:"example,omitempty"`
+}
+
+type RequestBody struct {
+	Description string                `json:"description,omitempty"`
+	Required    bool                  `json:"required,omitempty"`
+	Content     map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Headers     map[string]*Header    `json:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type MediaType struct {
+	Schema   *Schema                   `json:"schema,omitempty"`
+	Examples map[string]*ExampleObject `json:"examples,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema               `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecuritySchemeObject `json:"securitySchemes,omitempty"`
+}
+
+// mimeTypeAliases are the short names swag accepts in @Accept/@Produce.
+// Keeping the same aliases lets a route be documented once for both emitters.
+var mimeTypeAliases = map[string]string{
+	"json":                  "application/json",
+	"xml":                   "application/xml",
+	"plain":                 "text/plain",
+	"html":                  "text/html",
+	"mpfd":                  "multipart/form-data",
+	"x-www-form-urlencoded": "application/x-www-form-urlencoded",
+	"json-api":              "application/vnd.api+json",
+	"json-stream":           "application/x-json-stream",
+	"octet-stream":          "application/octet-stream",
+	"png":                   "image/png",
+	"jpeg":                  "image/jpeg",
+	"gif":                   "image/gif",
+}
+
+// pathParamPattern matches the :name and *name placeholders used by echo and gin.
+var pathParamPattern = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// BuildOpenAPI walks the collected routes and groups and builds the OpenAPI
+// 3.1 document describing them, without relying on the swag binary.
+func BuildOpenAPI(doc Doc) *OpenAPI {
+	b := &openAPIBuilder{
+		schemas: newSchemaRegistry(),
+		doc: &OpenAPI{
+			OpenAPI:      openAPIVersion,
+			Info:         openAPIInfo(doc.Info),
+			Servers:      openAPIServers(doc.Info),
+			Paths:        make(map[string]*PathItem),
+			Tags:         openAPITags(declaredTags(doc)),
+			ExternalDocs: openAPIExternalDocs(doc.Info.ExternalDocs),
+		},
+	}
+
+	routes, groups := prepareRoutes(doc)
+
+	b.addRoutes(routes)
+	b.addGroups(groups)
+
+	if len(b.schemas.components) > 0 || len(doc.SecuritySchemes) > 0 {
+		b.doc.Components = &Components{Schemas: b.schemas.components}
+	}
+
+	for name, scheme := range doc.SecuritySchemes {
+		if b.doc.Components.SecuritySchemes == nil {
+			b.doc.Components.SecuritySchemes = make(map[string]*SecuritySchemeObject)
+		}
+		b.doc.Components.SecuritySchemes[name] = openAPISecurityScheme(scheme)
+	}
+
+	return b.doc
+}
+
+// GenerateOpenAPI writes the OpenAPI 3.1 document as openapi.json and
+// openapi.yaml inside dir, creating it if needed.
+func GenerateOpenAPI(doc Doc, dir string) error {
+	jsonContent, err := json.MarshalIndent(BuildOpenAPI(doc), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding openapi json: %w", err)
+	}
+
+	yamlContent, err := jsonToYAML(jsonContent)
+	if err != nil {
+		return fmt.Errorf("encoding openapi yaml: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, openAPIJSONFile), append(jsonContent, '\n'), 0o644); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, openAPIYAMLFile), yamlContent, 0o644)
+}
+
+// GenerateSpecsOpenAPI writes the OpenAPI 3.1 document of each spec of doc:
+// the default one inside dir and the named ones inside the folder of the
+// spec in dir, as Generate does for `goswag docs --native`.
+func GenerateSpecsOpenAPI(doc Doc, dir string) error {
+	for _, spec := range Specs(doc) {
+		if err := GenerateOpenAPI(spec.Doc, filepath.Join(dir, spec.Name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type openAPIBuilder struct {
+	doc     *OpenAPI
+	schemas *schemaRegistry
+}
+
+func (b *openAPIBuilder) addGroups(groups []Group) {
+	for _, g := range groups {
+		b.addRoutes(g.Routes)
+		b.addGroups(g.Groups)
+	}
+}
+
+func (b *openAPIBuilder) addRoutes(routes []Route) {
+	for _, r := range routes {
+		if r.Path == "" || r.Method == "" {
+			continue
+		}
+
+		path := toOpenAPIPath(r.Path)
+
+		item, ok := b.doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			b.doc.Paths[path] = item
+		}
+
+		(*item)[strings.ToLower(r.Method)] = b.operation(r)
+	}
+}
+
+func (b *openAPIBuilder) operation(r Route) *Operation {
+	op := &Operation{
+		Tags:        r.Tags,
+		Summary:     r.Summary,
+		Description: r.Description,
+		Responses:   make(map[string]*Response),
+		Security:    openAPISecurity(r.Security),
+		OperationID: r.OperationID,
+		Deprecated:  r.Deprecated,
+	}
+
+	if extensions := routeExtensions(r); len(extensions) > 0 {
+		op.Extensions = extensions
+	}
+
+	if op.Description == "" {
+		op.Description = r.Summary
+	}
+
+	for _, p := range r.PathParams {
+		op.Parameters = append(op.Parameters, openAPIParameter("path", p))
+	}
+
+	for _, p := range r.QueryParams {
+		op.Parameters = append(op.Parameters, openAPIParameter("query", p))
+	}
+
+	for _, p := range r.HeaderParams {
+		op.Parameters = append(op.Parameters, openAPIParameter("header", p))
+	}
+
+	if r.Reads != nil {
+		op.RequestBody = &RequestBody{
+			Description: "Request",
+			Required:    true,
+			Content:     b.content(formAccepts(r), b.overriddenSchema(b.schemas.schemaOf(r.Reads), reflect.TypeOf(r.Reads), newOverrideTree(r.ReadOverrides))),
+		}
+		addExamples(op.RequestBody.Content, readExampleNames(r.ReadExamples))
+	} else if len(r.FormParams) > 0 {
+		schema := formSchema(r.FormParams)
+		op.RequestBody = &RequestBody{
+			Required: len(schema.Required) > 0,
+			Content:  b.content(formAccepts(r), schema),
+		}
+	}
+
+	for _, ret := range r.Returns {
+		code := responseCode(ret)
+		if code == "" {
+			continue
+		}
+
+		resp := &Response{Description: ret.Description}
+		if resp.Description == "" && !ret.Default {
+			resp.Description = http.StatusText(ret.StatusCode)
+		}
+		if resp.Description == "" {
+			resp.Description = "Response"
+		}
+
+		for _, h := range ret.Headers {
+			if resp.Headers == nil {
+				resp.Headers = make(map[string]*Header)
+			}
+			resp.Headers[h.Name] = &Header{Description: h.Description, Schema: paramSchema(h.Type)}
+		}
+
+		if ret.Body != nil {
+			resp.Content = b.content(r.Produces, b.responseSchema(ret))
+			addExamples(resp.Content, ret.Examples)
+		}
+
+		op.Responses[code] = resp
+	}
+
+	return op
+}
+
+// responseSchema returns the schema of a response body, composing it with
+// the OverrideStructFields the same way swag renders {data=pkg.T}.
+func (b *openAPIBuilder) responseSchema(ret models.ReturnType) *Schema {
+	if isBytes(reflect.TypeOf(ret.Body)) {
+		// a []byte response is a file download, not a base64 json string
+		return &Schema{Type: "string", Format: "binary"}
+	}
+
+	return b.overriddenSchema(b.schemas.schemaOf(ret.Body), reflect.TypeOf(ret.Body), newOverrideTree(ret.OverrideStructFields))
+}
+
+// addExamples sets the named examples on every media type of a body.
+func addExamples(content map[string]*MediaType, examples map[string]any) {
+	objects := openAPIExamples(examples)
+	if len(objects) == 0 {
+		return
+	}
+
+	for _, mediaType := range content {
+		mediaType.Examples = objects
+	}
+}
+
+// content returns one media type entry per mime type, defaulting to json.
+func (b *openAPIBuilder) content(mimeTypes []string, schema *Schema) map[string]*MediaType {
+	content := make(map[string]*MediaType)
+
+	for _, mimeType := range mimeTypes {
+		if strings.TrimSpace(mimeType) == "" {
+			continue
+		}
+		content[toMIMEType(mimeType)] = &MediaType{Schema: schema}
+	}
+
+	if len(content) == 0 {
+		content[mimeTypeAliases["json"]] = &MediaType{Schema: schema}
+	}
+
+	return content
+}
+
+func openAPIParameter(in string, p Param) *Parameter {
+	param := &Parameter{
+		Name:        p.Name,
+		In:          in,
+		Description: p.Description,
+		Required:    p.Required || in == "path", // path parameters are always required in OpenAPI
+		Schema:      paramAttributesSchema(p),
+		Example:     p.Example,
+	}
+
+	if p.ParamType == ArrayParamType && in == "query" {
+		param.Style, param.Explode = collectionStyle(p.CollectionFormat)
+	}
+
+	return param
+}
+
+// paramAttributesSchema returns the schema of a param with its constraints.
+// For arrays, the constraints apply to the elements.
+func paramAttributesSchema(p Param) *Schema {
+	schema := paramSchema(p.ParamType)
+	if p.ParamType == FileParamType {
+		schema = &Schema{Type: "string", Format: "binary"}
+	}
+
+	target := schema
+	if p.ParamType == ArrayParamType {
+		target = paramSchema(cmp.Or(p.Items, "string"))
+		schema.Items = target
+	}
+
+	target.Enum = p.Enum
+	target.Default = p.Default
+	target.Minimum = p.Minimum
+	target.Maximum = p.Maximum
+	target.MinLength = p.MinLength
+	target.MaxLength = p.MaxLength
+	target.Pattern = p.Pattern
+	if p.Format != "" {
+		target.Format = p.Format
+	}
+
+	return schema
+}
+
+// collectionStyle maps a swag collectionFormat to the OpenAPI style and
+// explode of a query param. multi and the default are form exploded.
+func collectionStyle(collectionFormat string) (string, *bool) {
+	explode := false
+
+	switch collectionFormat {
+	case "csv":
+		return "form", &explode
+	case "ssv":
+		return "spaceDelimited", &explode
+	case "pipes":
+		return "pipeDelimited", &explode
+	}
+
+	return "", nil
+}
+
+// formSchema describes form params as the object schema of a form-encoded
+// or multipart request body.
+func formSchema(params []Param) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for _, p := range params {
+		schema := paramAttributesSchema(p)
+		schema.Description = p.Description
+
+		s.Properties[p.Name] = schema
+		if p.Required {
+			s.Required = append(s.Required, p.Name)
+		}
+	}
+
+	return s
+}
+
+// paramSchema maps the goswag param data types to a JSON Schema.
+func paramSchema(paramType string) *Schema {
+	switch paramType {
+	case "int", "integer":
+		return &Schema{Type: "integer"}
+	case "number":
+		return &Schema{Type: "number"}
+	case "bool", "boolean":
+		return &Schema{Type: "boolean"}
+	case ArrayParamType:
+		return &Schema{Type: "array"}
+	}
+
+	return &Schema{Type: "string"}
+}
+
+func toMIMEType(alias string) string {
+	if mimeType, ok := mimeTypeAliases[alias]; ok {
+		return mimeType
+	}
+
+	return alias
+}
+
+// toOpenAPIPath converts the framework path placeholders to OpenAPI
+// templates, e.g. /users/:id becomes /users/{id}.
+func toOpenAPIPath(path string) string {
+	return pathParamPattern.ReplaceAllString(path, "{$1}")
+}
+
+// jsonToYAML re-encodes a JSON document as block-style YAML, keeping the key
+// order of the JSON input.
+func jsonToYAML(data []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+
+	clearYAMLStyle(&node)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(&node); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// clearYAMLStyle drops the flow and quoting styles the decoder records for
+// JSON input, so the encoder emits idiomatic block YAML.
+func clearYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearYAMLStyle(child)
+	}
+}
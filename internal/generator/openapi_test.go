@@ -0,0 +1,294 @@
+package generator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/diegoclair/goswag/internal/generator/testutil"
+	"github.com/diegoclair/goswag/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestToOpenAPIPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/users", want: "/users"},
+		{path: "/users/:id", want: "/users/{id}"},
+		{path: "/users/:id/posts/:post_id/", want: "/users/{id}/posts/{post_id}/"},
+		{path: "/static/*filepath", want: "/static/{filepath}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, toOpenAPIPath(tt.path))
+		})
+	}
+}
+
+func TestBuildOpenAPI(t *testing.T) {
+	routes := []Route{
+		{
+			Path:    "/health",
+			Method:  "GET",
+			Summary: "Health check",
+			Returns: []models.ReturnType{{StatusCode: 204}},
+		},
+	}
+	groups := []Group{
+		{
+			GroupName: "users",
+			Routes: []Route{
+				{
+					Path:        "/users/:id",
+					Method:      "PUT",
+					Summary:     "Update user",
+					Description: "Update the user",
+					Reads:       testutil.TestGeneric{},
+					PathParams:  []Param{{Name: "id", Description: "user id", ParamType: "int"}},
+					QueryParams: []Param{{Name: "dry_run", ParamType: "bool"}},
+					Returns: []models.ReturnType{
+						{
+							StatusCode:           200,
+							Body:                 testutil.OverrideStruct{},
+							OverrideStructFields: map[string]any{"body": testutil.TestGeneric{}},
+						},
+					},
+				},
+			},
+		},
+	}
+	defaults := []models.ReturnType{{StatusCode: 500, Body: testutil.TestGeneric{}}}
+
+	doc := BuildOpenAPI(Doc{Routes: routes, Groups: groups, DefaultResponses: defaults})
+
+	assert.Equal(t, "3.1.0", doc.OpenAPI)
+
+	health := (*doc.Paths["/health"])["get"]
+	require.NotNil(t, health)
+	assert.Equal(t, "Health check", health.Description)
+	assert.Equal(t, &Response{Description: "No Content"}, health.Responses["204"])
+	assert.Contains(t, health.Responses, "500")
+
+	update := (*doc.Paths["/users/{id}"])["put"]
+	require.NotNil(t, update)
+	assert.Equal(t, []string{"users"}, update.Tags)
+	assert.Equal(t, []*Parameter{
+		{Name: "id", In: "path", Description: "user id", Required: true, Schema: &Schema{Type: "integer"}},
+		{Name: "dry_run", In: "query", Schema: &Schema{Type: "boolean"}},
+	}, update.Parameters)
+	assert.Equal(t,
+		&Schema{Ref: "#/components/schemas/testutil.TestGeneric"},
+		update.RequestBody.Content["application/json"].Schema,
+	)
+	assert.Equal(t, &Schema{AllOf: []*Schema{
+		{Ref: "#/components/schemas/testutil.OverrideStruct"},
+		{Type: "object", Properties: map[string]*Schema{"body": {Ref: "#/components/schemas/testutil.TestGeneric"}}},
+	}}, update.Responses["200"].Content["application/json"].Schema)
+
+	assert.Contains(t, doc.Components.Schemas, "testutil.TestGeneric")
+	assert.Contains(t, doc.Components.Schemas, "testutil.OverrideStruct")
+}
+
+func TestBuildOpenAPI_mimeTypes(t *testing.T) {
+	doc := BuildOpenAPI(Doc{Routes: []Route{
+		{
+			Path:     "/files",
+			Method:   "POST",
+			Accepts:  []string{"xml", "text/csv"},
+			Produces: []string{"plain"},
+			Reads:    "",
+			Returns:  []models.ReturnType{{StatusCode: 201, Body: ""}},
+		},
+	}})
+
+	op := (*doc.Paths["/files"])["post"]
+	assert.Contains(t, op.RequestBody.Content, "application/xml")
+	assert.Contains(t, op.RequestBody.Content, "text/csv")
+	assert.Contains(t, op.Responses["201"].Content, "text/plain")
+	assert.Nil(t, doc.Components)
+}
+
+func TestBuildOpenAPI_bodyKinds(t *testing.T) {
+	doc := BuildOpenAPI(Doc{Routes: []Route{
+		{
+			Path:   "/items",
+			Method: "POST",
+			Reads:  []testutil.TestGeneric{},
+			Returns: []models.ReturnType{
+				{StatusCode: 200, Body: []byte{}},
+				{StatusCode: 201, Body: []int{}},
+			},
+		},
+	}})
+
+	op := (*doc.Paths["/items"])["post"]
+	assert.Equal(t,
+		&Schema{Type: "array", Items: &Schema{Ref: "#/components/schemas/testutil.TestGeneric"}},
+		op.RequestBody.Content["application/json"].Schema,
+	)
+	assert.Equal(t, &Schema{Type: "string", Format: "binary"}, op.Responses["200"].Content["application/json"].Schema)
+	assert.Equal(t,
+		&Schema{Type: "array", Items: &Schema{Type: "integer", Format: "int64"}},
+		op.Responses["201"].Content["application/json"].Schema,
+	)
+}
+
+func TestBuildOpenAPI_responseHeaders(t *testing.T) {
+	doc := BuildOpenAPI(Doc{Routes: []Route{
+		{
+			Path:   "/users",
+			Method: "POST",
+			Returns: []models.ReturnType{
+				{
+					StatusCode:  201,
+					Description: "User created",
+					Headers: []models.ResponseHeader{
+						{Name: "Location", Description: "URL of the user"},
+						{Name: "X-RateLimit-Remaining", Type: "int"},
+					},
+				},
+			},
+		},
+	}})
+
+	assert.Equal(t, &Response{
+		Description: "User created",
+		Headers: map[string]*Header{
+			"Location":              {Description: "URL of the user", Schema: &Schema{Type: "string"}},
+			"X-RateLimit-Remaining": {Schema: &Schema{Type: "integer"}},
+		},
+	}, (*doc.Paths["/users"])["post"].Responses["201"])
+}
+
+func TestBuildOpenAPI_defaultResponse(t *testing.T) {
+	doc := BuildOpenAPI(Doc{
+		Routes: []Route{
+			{Path: "/old", Method: "GET", Returns: []models.ReturnType{{StatusCode: 301}}},
+		},
+		DefaultResponses: []models.ReturnType{{Default: true, Body: testutil.TestGeneric{}, Description: "Unexpected error"}},
+	})
+
+	op := (*doc.Paths["/old"])["get"]
+	assert.Equal(t, []string{"301", "default"}, sortedKeys(op.Responses))
+	assert.Equal(t, "Moved Permanently", op.Responses["301"].Description)
+	assert.Equal(t, "Unexpected error", op.Responses["default"].Description)
+}
+
+func TestBuildOpenAPI_formParams(t *testing.T) {
+	doc := BuildOpenAPI(Doc{Routes: []Route{
+		{
+			Path:   "/login",
+			Method: "POST",
+			FormParams: []Param{
+				{Name: "username", ParamType: "string", Required: true},
+				{Name: "remember", ParamType: "bool"},
+			},
+		},
+		{
+			Path:       "/avatar",
+			Method:     "PUT",
+			FormParams: []Param{{Name: "file", Description: "The image", ParamType: FileParamType}},
+		},
+	}})
+
+	login := (*doc.Paths["/login"])["post"]
+	assert.Equal(t, &RequestBody{
+		Required: true,
+		Content: map[string]*MediaType{
+			"application/x-www-form-urlencoded": {Schema: &Schema{
+				Type: "object",
+				Properties: map[string]*Schema{
+					"username": {Type: "string"},
+					"remember": {Type: "boolean"},
+				},
+				Required: []string{"username"},
+			}},
+		},
+	}, login.RequestBody)
+
+	avatar := (*doc.Paths["/avatar"])["put"]
+	assert.Equal(t,
+		&Schema{Type: "string", Format: "binary", Description: "The image"},
+		avatar.RequestBody.Content["multipart/form-data"].Schema.Properties["file"],
+	)
+	assert.False(t, avatar.RequestBody.Required)
+}
+
+func TestBuildOpenAPI_paramAttributes(t *testing.T) {
+	limit := Param{Name: "limit", ParamType: "int"}
+	limit.Default = 20
+	limit.Minimum = ptr(1.0)
+	limit.Example = 10
+
+	status := Param{Name: "status", ParamType: ArrayParamType}
+	status.Items = "string"
+	status.CollectionFormat = "csv"
+	status.Enum = []any{"open", "closed"}
+
+	id := Param{Name: "id", ParamType: "string"}
+	id.Format = "uuid"
+	id.Pattern = "^[0-9a-f-]+$"
+
+	doc := BuildOpenAPI(Doc{Routes: []Route{
+		{Path: "/orders/:id", Method: "GET", PathParams: []Param{id}, QueryParams: []Param{limit, status}},
+	}})
+
+	explode := false
+	assert.Equal(t, []*Parameter{
+		{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "string", Format: "uuid", Pattern: "^[0-9a-f-]+$"}},
+		{Name: "limit", In: "query", Schema: &Schema{Type: "integer", Default: 20, Minimum: ptr(1.0)}, Example: 10},
+		{
+			Name: "status", In: "query", Style: "form", Explode: &explode,
+			Schema: &Schema{Type: "array", Items: &Schema{Type: "string", Enum: []any{"open", "closed"}}},
+		},
+	}, (*doc.Paths["/orders/{id}"])["get"].Parameters)
+}
+
+func TestGenerateOpenAPI(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "docs")
+
+	err := GenerateOpenAPI(Doc{Routes: []Route{
+		{Path: "/test/:id", Method: "GET", Returns: []models.ReturnType{{StatusCode: 200, Body: testutil.TestGeneric{}}}},
+	}}, dir)
+	require.NoError(t, err)
+
+	jsonContent, err := os.ReadFile(filepath.Join(dir, "openapi.json"))
+	require.NoError(t, err)
+
+	yamlContent, err := os.ReadFile(filepath.Join(dir, "openapi.yaml"))
+	require.NoError(t, err)
+
+	var fromJSON, fromYAML map[string]any
+	require.NoError(t, json.Unmarshal(jsonContent, &fromJSON))
+	require.NoError(t, yaml.Unmarshal(yamlContent, &fromYAML))
+
+	assert.Equal(t, fromJSON, fromYAML)
+	assert.Contains(t, string(yamlContent), "openapi: 3.1.0\n")
+	assert.Contains(t, string(yamlContent), `"200":`)
+}
+
+func TestGenerateSpecsOpenAPI(t *testing.T) {
+	dir := t.TempDir()
+
+	err := GenerateSpecsOpenAPI(Doc{
+		Routes: []Route{{Path: "/health", Method: "GET"}},
+		Groups: []Group{{GroupName: "/v1", Spec: "v1", Routes: []Route{{Path: "/v1/users", Method: "GET"}}}},
+	}, dir)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "openapi.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"/health"`)
+	assert.NotContains(t, string(content), `"/v1/users"`, "Should leave the routes of named specs out of the default document")
+
+	content, err = os.ReadFile(filepath.Join(dir, "v1", "openapi.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"/v1/users"`)
+	assert.NotContains(t, string(content), `"/health"`)
+}
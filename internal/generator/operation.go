@@ -0,0 +1,175 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// sunsetLayout is the format of the sunset date of deprecated routes.
+const sunsetLayout = "2006-01-02"
+
+var (
+	// handlerHashSuffix is the disambiguation hash shared.UniqueIdentifier
+	// appends to handler names.
+	handlerHashSuffix = regexp.MustCompile(`_[0-9a-f]{8}$`)
+	// anonymousFunc matches the names Go gives to closures: func1, func2...
+	anonymousFunc = regexp.MustCompile(`^func\d+$`)
+)
+
+// defaultOperationID returns the operation id of a route without one: the
+// name of its handler, or "" for anonymous handlers, whose names mean nothing.
+func defaultOperationID(funcName string) string {
+	name := handlerHashSuffix.ReplaceAllString(funcName, "")
+	if anonymousFunc.MatchString(name) {
+		return ""
+	}
+
+	return name
+}
+
+// assignOperationIDs returns a copy of the routes and groups where every
+// route with a named handler has an operation id. The declared ids are kept.
+// The default ids that clash, with another default id or a declared one,
+// are all suffixed with the method and path of their route
+// (handleLogin_post_v2_login), so an id doesn't depend on the order the
+// routes are registered in and client methods keep their names when routes
+// move; swag rejects duplicated ids.
+func assignOperationIDs(routes []Route, groups []Group) ([]Route, []Group) {
+	declared := make(map[string]bool)
+	defaults := make(map[string]int)
+	walkRoutes(routes, groups, func(r Route) {
+		if r.OperationID != "" {
+			declared[r.OperationID] = true
+		} else if id := defaultOperationID(r.FuncName); id != "" {
+			defaults[id]++
+		}
+	})
+
+	clashes := func(id string) bool {
+		return declared[id] || defaults[id] > 1
+	}
+
+	taken := make(map[string]bool)
+	for id := range declared {
+		taken[id] = true
+	}
+	for id := range defaults {
+		if !clashes(id) {
+			taken[id] = true
+		}
+	}
+
+	var assign func(routes []Route, groups []Group) ([]Route, []Group)
+	assign = func(routes []Route, groups []Group) ([]Route, []Group) {
+		var (
+			newRoutes []Route
+			newGroups []Group
+		)
+
+		for _, r := range routes {
+			if r.OperationID == "" {
+				r.OperationID = defaultOperationID(r.FuncName)
+				if r.OperationID != "" && clashes(r.OperationID) {
+					r.OperationID = uniqueOperationID(r.OperationID+"_"+routeSuffix(r), taken)
+				}
+			}
+			newRoutes = append(newRoutes, r)
+		}
+
+		for _, g := range groups {
+			g.Routes, g.Groups = assign(g.Routes, g.Groups)
+			newGroups = append(newGroups, g)
+		}
+
+		return newRoutes, newGroups
+	}
+
+	return assign(routes, groups)
+}
+
+// routeSuffix joins the words of the method and path of r: post_v2_login for
+// POST /v2/login.
+func routeSuffix(r Route) string {
+	words := strings.FieldsFunc(strings.ToLower(r.Method)+" "+r.Path, func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsDigit(c)
+	})
+
+	return strings.Join(words, "_")
+}
+
+// uniqueOperationID returns id, numbered when it is still taken, which only
+// happens when a declared id looks like a suffixed one.
+func uniqueOperationID(id string, taken map[string]bool) string {
+	unique := id
+	for n := 2; taken[unique]; n++ {
+		unique = id + "_" + strconv.Itoa(n)
+	}
+	taken[unique] = true
+
+	return unique
+}
+
+// walkRoutes calls fn for every route, in registration order.
+func walkRoutes(routes []Route, groups []Group, fn func(Route)) {
+	for _, r := range routes {
+		fn(r)
+	}
+
+	for _, g := range groups {
+		walkRoutes(g.Routes, g.Groups, fn)
+	}
+}
+
+// extensionKey returns key with the x- prefix vendor extensions require.
+func extensionKey(key string) string {
+	if strings.HasPrefix(strings.ToLower(key), "x-") {
+		return key
+	}
+
+	return "x-" + key
+}
+
+// routeExtensions returns the vendor extensions of a route, including the
+// ones describing its deprecation.
+func routeExtensions(r Route) map[string]any {
+	extensions := make(map[string]any, len(r.Extensions)+2)
+	for key, value := range r.Extensions {
+		extensions[extensionKey(key)] = value
+	}
+
+	if r.Deprecated {
+		if r.DeprecationReason != "" {
+			extensions["x-deprecated-reason"] = r.DeprecationReason
+		}
+		if !r.Sunset.IsZero() {
+			extensions["x-sunset"] = r.Sunset.Format(sunsetLayout)
+		}
+	}
+
+	return extensions
+}
+
+// writeOperation writes the @ID, @Deprecated and @x- lines of a route.
+// Extensions whose value can't be encoded as JSON are skipped, Validate
+// reports them.
+func writeOperation(s *strings.Builder, r Route) {
+	addLineIfNotEmpty(s, r.OperationID, "// @ID %s\n")
+
+	if r.Deprecated {
+		s.WriteString("// @Deprecated\n")
+	}
+
+	extensions := routeExtensions(r)
+	for _, key := range sortedKeys(extensions) {
+		value, err := json.Marshal(extensions[key])
+		if err != nil {
+			continue
+		}
+
+		s.WriteString(fmt.Sprintf("// @%s %s\n", key, value))
+	}
+}
@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultOperationID(t *testing.T) {
+	tests := []struct {
+		funcName string
+		want     string
+	}{
+		{funcName: "handleLogin_a3f2c9d1", want: "handleLogin"},
+		{funcName: "handleLogin", want: "handleLogin"},
+		{funcName: "func1_a3f2c9d1", want: ""},
+		{funcName: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.funcName, func(t *testing.T) {
+			assert.Equal(t, tt.want, defaultOperationID(tt.funcName))
+		})
+	}
+}
+
+func TestAssignOperationIDs(t *testing.T) {
+	routes := []Route{
+		{Method: "POST", Path: "/login", FuncName: "handleLogin_a3f2c9d1"},
+		{Method: "POST", Path: "/v2/login", FuncName: "handleLogin_b71e04f8"},
+		{Method: "POST", Path: "/logout", FuncName: "handleLogout_a3f2c9d1", OperationID: "logout"},
+		{Method: "GET", Path: "/anonymous", FuncName: "func1_a3f2c9d1"},
+		{Method: "GET", Path: "/me", FuncName: "handleMe_a3f2c9d1"},
+	}
+	groups := []Group{{Routes: []Route{
+		{Method: "POST", Path: "/admin/logout", FuncName: "logout_c0ffee00"},
+		{Method: "POST", Path: "/admin/login", FuncName: "handleAdminLogin_c0ffee00", OperationID: "handleLogin_post_login"},
+	}}}
+
+	gotRoutes, gotGroups := assignOperationIDs(routes, groups)
+
+	assert.Equal(t, "handleLogin_post_login_2", gotRoutes[0].OperationID, "Should number a suffixed id taken by a declared one")
+	assert.Equal(t, "handleLogin_post_v2_login", gotRoutes[1].OperationID, "Should suffix the default ids that clash with their method and path")
+	assert.Equal(t, "logout", gotRoutes[2].OperationID, "Should keep the declared id")
+	assert.Equal(t, "", gotRoutes[3].OperationID, "Should not name anonymous handlers")
+	assert.Equal(t, "handleMe", gotRoutes[4].OperationID, "Should default to the handler name")
+	assert.Equal(t, "logout_post_admin_logout", gotGroups[0].Routes[0].OperationID, "Should not reuse the declared ids")
+	assert.Equal(t, "handleLogin_post_login", gotGroups[0].Routes[1].OperationID, "Should keep the declared id in groups")
+
+	assert.Empty(t, routes[0].OperationID, "Should not modify the given routes")
+
+	// the ids of the routes don't depend on their order
+	reordered, _ := assignOperationIDs([]Route{routes[1], routes[0]}, nil)
+	assert.Equal(t, "handleLogin_post_v2_login", reordered[0].OperationID)
+	assert.Equal(t, "handleLogin_post_login", reordered[1].OperationID)
+}
+
+func TestWriteOperation(t *testing.T) {
+	tests := []struct {
+		name  string
+		route Route
+		want  string
+	}{
+		{
+			name: "Should write nothing for a plain route",
+		},
+		{
+			name: "Should write the id, the deprecation and the extensions",
+			route: Route{
+				OperationID:       "listUsers",
+				Deprecated:        true,
+				DeprecationReason: "Use /v2/users",
+				Sunset:            time.Date(2027, time.January, 31, 0, 0, 0, 0, time.UTC),
+				Extensions:        map[string]any{"internal": true, "x-rate-limit": map[string]int{"rps": 10}},
+			},
+			want: "// @ID listUsers\n" +
+				"// @Deprecated\n" +
+				"// @x-deprecated-reason \"Use /v2/users\"\n" +
+				"// @x-internal true\n" +
+				"// @x-rate-limit {\"rps\":10}\n" +
+				"// @x-sunset \"2027-01-31\"\n",
+		},
+		{
+			name:  "Should skip the extensions that are not JSON",
+			route: Route{Deprecated: true, Extensions: map[string]any{"x-bad": make(chan int)}},
+			want:  "// @Deprecated\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &strings.Builder{}
+			writeOperation(s, tt.route)
+			assert.Equal(t, tt.want, s.String())
+		})
+	}
+}
+
+func TestBuildOpenAPI_operation(t *testing.T) {
+	doc := BuildOpenAPI(Doc{Routes: []Route{
+		{
+			Path:       "/users",
+			Method:     "GET",
+			FuncName:   "handleListUsers_a3f2c9d1",
+			Deprecated: true,
+			Extensions: map[string]any{"internal": true},
+		},
+	}})
+
+	op := (*doc.Paths["/users"])["get"]
+	assert.Equal(t, "handleListUsers", op.OperationID)
+	assert.True(t, op.Deprecated)
+
+	content, err := json.Marshal(op)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"deprecated":true`)
+	assert.Contains(t, string(content), `"operationId":"handleListUsers"`)
+	assert.Contains(t, string(content), `"x-internal":true`)
+}
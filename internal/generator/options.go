@@ -0,0 +1,131 @@
+package generator
+
+import (
+	"io"
+	"log"
+	"os"
+)
+
+const (
+	defaultFileName    = "goswag.go"
+	defaultPackageName = "main"
+)
+
+// Logger is the subset of *log.Logger used to report the generation progress.
+type Logger interface {
+	Printf(format string, v ...any)
+}
+
+// Config holds the settings of a generation run. Use the With* options to
+// change it; the zero value of each field falls back to its default.
+type Config struct {
+	OutputDir   string
+	FileName    string
+	PackageName string
+	Logger      Logger
+	Strict      bool
+	DryRun      io.Writer
+	Audience    string
+	Audiences   []string
+	Manifest    io.Writer
+	OpenAPIDir  string // where `goswag docs --native` wants the OpenAPI documents, if set
+}
+
+// Option configures a generation run.
+type Option func(*Config)
+
+// WithOutputDir sets the directory where the stub file is written.
+// It is created if it does not exist. Default: the current directory.
+func WithOutputDir(dir string) Option {
+	return func(c *Config) { c.OutputDir = dir }
+}
+
+// WithFileName sets the name of the stub file. Default: goswag.go.
+func WithFileName(name string) Option {
+	return func(c *Config) { c.FileName = name }
+}
+
+// WithPackageName sets the package clause of the stub file. Default: main.
+func WithPackageName(name string) Option {
+	return func(c *Config) { c.PackageName = name }
+}
+
+// WithLogger sets the logger used to report the generation progress.
+// Default: log.Default().
+func WithLogger(l Logger) Option {
+	return func(c *Config) { c.Logger = l }
+}
+
+// WithStrict makes the generation fail with a *ValidationError, before
+// anything is written, when the route declarations have error findings.
+// Without it, the findings are only logged, but the blocking ones.
+func WithStrict() Option {
+	return func(c *Config) { c.Strict = true }
+}
+
+// WithDryRun makes the generation write the effective responses of every
+// route to w, defaults included, instead of writing the stub file.
+func WithDryRun(w io.Writer) Option {
+	return func(c *Config) { c.DryRun = w }
+}
+
+// WithAudience makes the generation document only the routes of audience,
+// the ones declaring it and the ones without audiences. Default: every
+// route that is not hidden.
+func WithAudience(audience string) Option {
+	return func(c *Config) { c.Audience = audience }
+}
+
+// WithAudiences makes the generation write a stub per audience, documenting
+// the routes of that audience only, in a folder of the output directory
+// named after it: public/goswag.go, internal/goswag.go... The stubs live in
+// a package named after their audience.
+func WithAudiences(audiences ...string) Option {
+	return func(c *Config) { c.Audiences = audiences }
+}
+
+// WithManifest makes the generation write the route manifest, as JSON, to
+// w instead of writing the stub file.
+func WithManifest(w io.Writer) Option {
+	return func(c *Config) { c.Manifest = w }
+}
+
+func newConfig(opts ...Option) *Config {
+	cfg := &Config{}
+	for _, o := range opts {
+		o(cfg)
+	}
+
+	if cfg.OutputDir == "" {
+		cfg.OutputDir = "."
+	}
+
+	if cfg.FileName == "" {
+		cfg.FileName = defaultFileName
+	}
+
+	if cfg.PackageName == "" {
+		cfg.PackageName = defaultPackageName
+	}
+
+	if cfg.Logger == nil {
+		cfg.Logger = log.Default()
+	}
+
+	if cfg.Audience == "" && len(cfg.Audiences) == 0 {
+		cfg.Audience = os.Getenv(AudienceEnv)
+		if audiences := SplitAudiences(cfg.Audience); audiences != nil {
+			cfg.Audience, cfg.Audiences = "", audiences
+		}
+	}
+
+	if cfg.OpenAPIDir == "" {
+		cfg.OpenAPIDir = os.Getenv(OpenAPIOutputEnv)
+	}
+
+	if cfg.DryRun == nil && os.Getenv(DryRunEnv) != "" {
+		cfg.DryRun = os.Stdout
+	}
+
+	return cfg
+}
@@ -0,0 +1,218 @@
+package generator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// overrideTree arranges the OverrideStructFields of a body by the dotted path
+// of their keys: "data.items" overrides the items field of the data field.
+type overrideTree struct {
+	value    any
+	set      bool // the field type is overridden, not only its fields
+	children map[string]*overrideTree
+}
+
+func newOverrideTree(overrides map[string]any) *overrideTree {
+	root := &overrideTree{}
+
+	for key, value := range overrides {
+		node := root
+		for _, name := range strings.Split(key, ".") {
+			if node.children == nil {
+				node.children = make(map[string]*overrideTree)
+			}
+			if node.children[name] == nil {
+				node.children[name] = &overrideTree{}
+			}
+			node = node.children[name]
+		}
+
+		node.value = value
+		node.set = true
+	}
+
+	return root
+}
+
+// fieldType returns the type of the field of parent overridden by the node:
+// the type of the override, or else the declared type of the field, when
+// only the fields of the field are overridden. It is nil when the field does
+// not exist.
+func (o *overrideTree) fieldType(parent reflect.Type, name string) reflect.Type {
+	if o.set {
+		return reflect.TypeOf(o.value)
+	}
+
+	return jsonField(parent, name)
+}
+
+// jsonField returns the type of the field of t encoded as name, looking into
+// pointers, slice elements and untagged embedded structs, or nil when t has
+// no such field.
+func jsonField(t reflect.Type, name string) reflect.Type {
+	t = elemType(t)
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		fieldName, _, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+
+		if field.Anonymous && fieldName == "" {
+			if ft := jsonField(field.Type, name); ft != nil {
+				return ft
+			}
+			continue
+		}
+
+		if fieldName == "" {
+			fieldName = field.Name
+		}
+
+		if fieldName == name {
+			return field.Type
+		}
+	}
+
+	return nil
+}
+
+// elemType strips the pointers and slices of t, down to the type whose
+// fields are overridden.
+func elemType(t reflect.Type) reflect.Type {
+	for t != nil {
+		switch {
+		case t.Kind() == reflect.Pointer:
+			t = t.Elem()
+		case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && !isBytes(t):
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+
+	return nil
+}
+
+// writeOverrides writes the {field=pkg.T} suffix of a body, nesting the
+// dotted overrides the way swag composes them: {data=pkg.Page{items=[]pkg.Order}}.
+func writeOverrides(s *strings.Builder, body any, overrides map[string]any, packagesToImport map[string]bool) {
+	if len(overrides) == 0 {
+		return
+	}
+
+	s.WriteString("{" + newOverrideTree(overrides).swagFields(reflect.TypeOf(body), packagesToImport) + "}")
+}
+
+// swagFields renders the overridden fields of t. Fields that don't exist are
+// skipped, Validate reports them.
+func (o *overrideTree) swagFields(t reflect.Type, packagesToImport map[string]bool) string {
+	fields := make([]string, 0, len(o.children))
+
+	for _, name := range sortedKeys(o.children) {
+		child := o.children[name]
+
+		ft := child.fieldType(t, name)
+		if ft == nil && !child.set {
+			continue
+		}
+
+		var field string
+		if child.set && isStatic(child.value) {
+			field = name + "=" + bodyTypeName(child.value, packagesToImport)
+		} else {
+			field = name + "=" + typeName(ft, packagesToImport)
+		}
+		if len(child.children) > 0 {
+			field += "{" + child.swagFields(ft, packagesToImport) + "}"
+		}
+		fields = append(fields, field)
+	}
+
+	return strings.Join(fields, ",")
+}
+
+// overriddenSchema composes base, the schema of t, with the overridden
+// fields, the same way swag renders {data=pkg.T}.
+func (b *openAPIBuilder) overriddenSchema(base *Schema, t reflect.Type, o *overrideTree) *Schema {
+	if len(o.children) == 0 {
+		return base
+	}
+
+	overrides := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for name, child := range o.children {
+		ft := child.fieldType(t, name)
+		if ft == nil && !child.set {
+			continue
+		}
+
+		overrides.Properties[name] = b.overrideSchema(ft, child)
+	}
+
+	return &Schema{AllOf: []*Schema{base, overrides}}
+}
+
+// overrideSchema returns the schema of an overridden field of type t,
+// composed with the overrides of its own fields. The fields of slices are
+// the ones of their elements.
+func (b *openAPIBuilder) overrideSchema(t reflect.Type, o *overrideTree) *Schema {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if len(o.children) > 0 && t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && !isBytes(t) {
+		return &Schema{Type: "array", Items: b.overrideSchema(t.Elem(), o)}
+	}
+
+	if t == nil {
+		return b.overriddenSchema(&Schema{}, nil, o)
+	}
+
+	return b.overriddenSchema(b.schemas.schemaFor(t), t, o)
+}
+
+// checkOverrides returns an error for every override key that is not a JSON
+// field of body, following the dotted path of nested keys.
+func checkOverrides(body any, overrides map[string]any) []error {
+	var (
+		errs []error
+		walk func(o *overrideTree, t reflect.Type, path string)
+	)
+
+	walk = func(o *overrideTree, t reflect.Type, path string) {
+		for _, name := range sortedKeys(o.children) {
+			child := o.children[name]
+
+			key := name
+			if path != "" {
+				key = path + "." + name
+			}
+
+			if jsonField(t, name) == nil {
+				errs = append(errs, fmt.Errorf("override %q does not match a JSON field of %s", key, typeName(elemType(t), make(map[string]bool))))
+				continue
+			}
+
+			if child.set && isStatic(child.value) {
+				continue // the fields of a type known from the source only can't be checked
+			}
+
+			walk(child, child.fieldType(t, name), key)
+		}
+	}
+
+	if isStatic(body) {
+		return nil
+	}
+
+	walk(newOverrideTree(overrides), reflect.TypeOf(body), "")
+
+	return errs
+}
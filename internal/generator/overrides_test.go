@@ -0,0 +1,112 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/diegoclair/goswag/internal/generator/testutil"
+	"github.com/diegoclair/goswag/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckOverrides(t *testing.T) {
+	type page = testutil.Page[any, testutil.Cursor]
+
+	tests := []struct {
+		name      string
+		body      any
+		overrides map[string]any
+		want      []string
+	}{
+		{
+			name:      "Should accept JSON fields, nested and through pointers",
+			body:      &testutil.Envelope[page]{},
+			overrides: map[string]any{"Data.Items": []testutil.TestGeneric{}, "Data.Next.Token": 0},
+		},
+		{
+			name:      "Should accept the fields of an overridden field",
+			body:      testutil.OverrideStruct{},
+			overrides: map[string]any{"body": testutil.Envelope[any]{}, "body.Data": testutil.TestGeneric{}},
+		},
+		{
+			name:      "Should accept the fields of the elements of slices",
+			body:      []testutil.Envelope[any]{},
+			overrides: map[string]any{"Data": testutil.TestGeneric{}},
+		},
+		{
+			name:      "Should report keys that are not JSON fields",
+			body:      testutil.Envelope[page]{},
+			overrides: map[string]any{"data": testutil.TestGeneric{}, "Data.items": []testutil.TestGeneric{}},
+			want: []string{
+				`override "Data.items" does not match a JSON field of testutil.Page[interface{},testutil.Cursor]`,
+				`override "data" does not match a JSON field of testutil.Envelope[testutil.Page[interface{},testutil.Cursor]]`,
+			},
+		},
+		{
+			name:      "Should report fields of fields that are not structs",
+			body:      testutil.OverrideStruct{},
+			overrides: map[string]any{"body.Data": testutil.TestGeneric{}},
+			want:      []string{`override "body.Data" does not match a JSON field of interface{}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, err := range checkOverrides(tt.body, tt.overrides) {
+				got = append(got, err.Error())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBuildOpenAPI_nestedOverrides(t *testing.T) {
+	body := testutil.Envelope[testutil.Page[any, testutil.Cursor]]{}
+
+	doc := BuildOpenAPI(Doc{Routes: []Route{{
+		Method:        "POST",
+		Path:          "/orders/search",
+		Reads:         testutil.OverrideStruct{},
+		ReadOverrides: map[string]any{"body": testutil.TestGeneric{}},
+		Returns: []models.ReturnType{{
+			StatusCode:           200,
+			Body:                 body,
+			OverrideStructFields: map[string]any{"Data.Items": []testutil.TestGeneric{}},
+		}},
+	}}})
+
+	op := (*doc.Paths["/orders/search"])["post"]
+	assert.Equal(t, &Schema{AllOf: []*Schema{
+		{Ref: "#/components/schemas/testutil.OverrideStruct"},
+		{Type: "object", Properties: map[string]*Schema{"body": {Ref: "#/components/schemas/testutil.TestGeneric"}}},
+	}}, op.RequestBody.Content["application/json"].Schema)
+
+	pageRef := "#/components/schemas/" + schemaName(reflect.TypeOf(body.Data))
+	assert.Equal(t, &Schema{AllOf: []*Schema{
+		{Ref: "#/components/schemas/" + schemaName(reflect.TypeOf(body))},
+		{Type: "object", Properties: map[string]*Schema{"Data": {AllOf: []*Schema{
+			{Ref: pageRef},
+			{Type: "object", Properties: map[string]*Schema{"Items": {
+				Type:  "array",
+				Items: &Schema{Ref: "#/components/schemas/testutil.TestGeneric"},
+			}}},
+		}}}},
+	}}, op.Responses["200"].Content["application/json"].Schema)
+}
+
+func TestWrite_readOverrides(t *testing.T) {
+	var b strings.Builder
+
+	_, err := Write(&b, Doc{Routes: []Route{{
+		Method:        "POST",
+		Path:          "/commands",
+		Reads:         testutil.OverrideStruct{},
+		ReadOverrides: map[string]any{"body": testutil.TestGeneric{}},
+	}}})
+	require.NoError(t, err)
+
+	assert.Contains(t, b.String(), "// @Param request body testutil.OverrideStruct{body=testutil.TestGeneric} true \"Request\"\n")
+}
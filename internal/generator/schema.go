@@ -0,0 +1,278 @@
+package generator
+
+import (
+	"encoding"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Schema is the subset of the JSON Schema (draft 2020-12) vocabulary that
+// OpenAPI 3.1 uses and goswag is able to derive from Go types.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	ContentEncoding      string             `json:"contentEncoding,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	invalidSchemaNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+)
+
+// schemaRegistry reflects Go types into JSON Schemas. Named struct types are
+// registered once under components/schemas and referenced with $ref, which
+// also keeps recursive types (a Node with Children []Node) finite.
+//
+// Components are named like swag names them, dto.User, unless types of
+// packages with the same name share that name: they are then named after
+// their package path too, billing.dto.User and identity.dto.User.
+type schemaRegistry struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string    // the component of each type
+	owners     map[string]reflect.Type    // the type of each name, nil once it is shared
+	refs       map[reflect.Type][]*Schema // the references to each type, renamed with it
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		components: make(map[string]*Schema),
+		names:      make(map[reflect.Type]string),
+		owners:     make(map[string]reflect.Type),
+		refs:       make(map[reflect.Type][]*Schema),
+	}
+}
+
+// schemaOf returns the schema of the dynamic type of v.
+func (r *schemaRegistry) schemaOf(v any) *Schema {
+	if v == nil {
+		return &Schema{}
+	}
+	return r.schemaFor(reflect.TypeOf(v))
+}
+
+func (r *schemaRegistry) schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	if t.Kind() != reflect.String && (t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)) {
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json writes []byte as a base64 string
+			return &Schema{Type: "string", ContentEncoding: "base64"}
+		}
+		return &Schema{Type: "array", Items: r.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+
+		ref := &Schema{}
+		r.refs[t] = append(r.refs[t], ref)
+
+		if _, ok := r.names[t]; !ok {
+			// register a placeholder before walking the fields so that
+			// self-referencing types resolve to the same component; the
+			// component may be renamed while they are walked
+			component := &Schema{}
+			r.register(t, component)
+			*component = *r.structSchema(t)
+		}
+
+		ref.Ref = componentRef(r.names[t])
+		return ref
+	}
+
+	// interfaces, funcs and channels accept any JSON value
+	return &Schema{}
+}
+
+// register adds the component of t. When another type has the same name,
+// both are renamed after their package path.
+func (r *schemaRegistry) register(t reflect.Type, schema *Schema) {
+	name := schemaName(t)
+
+	other, taken := r.owners[name]
+	if taken && other != nil {
+		r.rename(other, r.qualifiedName(other))
+		r.owners[name] = nil
+	}
+	if taken {
+		name = r.qualifiedName(t)
+	}
+
+	r.names[t], r.owners[name], r.components[name] = name, t, schema
+}
+
+// rename moves the component of t and its references to name.
+func (r *schemaRegistry) rename(t reflect.Type, name string) {
+	old := r.names[t]
+
+	r.components[name] = r.components[old]
+	delete(r.components, old)
+
+	r.names[t], r.owners[name] = name, t
+	for _, ref := range r.refs[t] {
+		ref.Ref = componentRef(name)
+	}
+}
+
+// qualifiedName prefixes the name of t with as many elements of its package
+// path as needed for no other type to have it: billing.dto.User. Generic
+// instances that differ only by their type arguments get their full name.
+func (r *schemaRegistry) qualifiedName(t reflect.Type) string {
+	parents := parentSegments(t.PkgPath())
+
+	for n := 1; n <= len(parents); n++ {
+		prefix := strings.Join(parents[len(parents)-n:], ".")
+		name := invalidSchemaNameChars.ReplaceAllString(prefix+"."+schemaName(t), "_")
+
+		if _, taken := r.owners[name]; !taken {
+			return name
+		}
+	}
+
+	return invalidSchemaNameChars.ReplaceAllString(strings.ReplaceAll(t.PkgPath(), "/", ".")+"."+t.Name(), "_")
+}
+
+func componentRef(name string) string {
+	return "#/components/schemas/" + name
+}
+
+// structSchema builds the inline object schema of a struct, following the
+// same field rules as encoding/json: unexported fields and `json:"-"` are
+// skipped and untagged embedded structs are flattened into the parent.
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name, opts, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded := r.structSchema(ft)
+				for k, v := range embedded.Properties {
+					s.Properties[k] = v
+				}
+				s.Required = append(s.Required, embedded.Required...)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldSchema := r.schemaFor(field.Type)
+		if strings.Contains(opts, "string") && fieldSchema.Ref == "" {
+			fieldSchema = &Schema{Type: "string"}
+		}
+		if description := field.Tag.Get("description"); description != "" {
+			fieldSchema = withDescription(fieldSchema, description)
+		}
+
+		s.Properties[name] = fieldSchema
+		if isRequiredField(field) {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	return s
+}
+
+// jsonFieldName returns the JSON name and options of a struct field, and
+// whether encoding/json would ignore it.
+func jsonFieldName(field reflect.StructField) (name, opts string, skip bool) {
+	if !field.IsExported() && !field.Anonymous {
+		return "", "", true
+	}
+
+	// an unexported embedded field is only flattened when it is a struct
+	if !field.IsExported() && derefType(field.Type).Kind() != reflect.Struct {
+		return "", "", true
+	}
+
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", "", true
+	}
+
+	name, opts, _ = strings.Cut(tag, ",")
+
+	return name, opts, false
+}
+
+// isRequiredField reports whether the field is marked as required by the
+// validation tags used by gin (binding) and go-playground/validator (validate).
+func isRequiredField(field reflect.StructField) bool {
+	for _, key := range []string{"binding", "validate"} {
+		for _, rule := range strings.Split(field.Tag.Get(key), ",") {
+			if strings.TrimSpace(rule) == "required" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// withDescription attaches a description to a schema. A $ref can't carry
+// siblings in every tool, so references are wrapped in an allOf.
+func withDescription(s *Schema, description string) *Schema {
+	if s.Ref != "" {
+		return &Schema{AllOf: []*Schema{s}, Description: description}
+	}
+
+	s.Description = description
+
+	return s
+}
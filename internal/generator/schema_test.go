@@ -0,0 +1,153 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/diegoclair/goswag/internal/generator/testutil"
+	billingdto "github.com/diegoclair/goswag/internal/generator/testutil/billing/dto"
+	identitydto "github.com/diegoclair/goswag/internal/generator/testutil/identity/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+type schemaTestNode struct {
+	Name     string           `json:"name" binding:"required"`
+	Children []schemaTestNode `json:"children,omitempty"`
+}
+
+type schemaTestEmbedded struct {
+	ID string `json:"id" validate:"required,uuid"`
+}
+
+// schemaTestLabel is embedded unexported, which encoding/json ignores.
+type schemaTestLabel string
+
+type schemaTestUser struct {
+	schemaTestEmbedded
+	schemaTestLabel
+	Email     string            `json:"email" description:"the user email"`
+	Age       int               `json:"age,omitempty"`
+	Score     float64           `json:"score"`
+	Active    bool              `json:"active"`
+	CreatedAt time.Time         `json:"created_at"`
+	Avatar    []byte            `json:"avatar"`
+	Labels    map[string]string `json:"labels"`
+	Count     int64             `json:"count,string"`
+	Extra     any               `json:"extra"`
+	Ignored   string            `json:"-"`
+	internal  string
+	NoTag     string
+}
+
+func TestSchemaRegistry_schemaOf(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      any
+		want       *Schema
+		components map[string]*Schema
+	}{
+		{
+			name:  "Should map primitive types",
+			value: "",
+			want:  &Schema{Type: "string"},
+		},
+		{
+			name:  "Should map slices to arrays",
+			value: []int32{},
+			want:  &Schema{Type: "array", Items: &Schema{Type: "integer", Format: "int32"}},
+		},
+		{
+			name:  "Should map time.Time to a date-time string",
+			value: time.Time{},
+			want:  &Schema{Type: "string", Format: "date-time"},
+		},
+		{
+			name:  "Should map nil to an empty schema",
+			value: nil,
+			want:  &Schema{},
+		},
+		{
+			name: "Should inline anonymous structs",
+			value: struct {
+				Name string `json:"name"`
+			}{},
+			want: &Schema{Type: "object", Properties: map[string]*Schema{"name": {Type: "string"}}},
+		},
+		{
+			name:  "Should reference named structs and register them as components",
+			value: &testutil.TestGeneric{},
+			want:  &Schema{Ref: "#/components/schemas/testutil.TestGeneric"},
+			components: map[string]*Schema{
+				"testutil.TestGeneric": {Type: "object", Properties: map[string]*Schema{"Name": {Type: "string"}}},
+			},
+		},
+		{
+			name:  "Should resolve recursive types to the same component",
+			value: schemaTestNode{},
+			want:  &Schema{Ref: "#/components/schemas/generator.schemaTestNode"},
+			components: map[string]*Schema{
+				"generator.schemaTestNode": {
+					Type: "object",
+					Properties: map[string]*Schema{
+						"name":     {Type: "string"},
+						"children": {Type: "array", Items: &Schema{Ref: "#/components/schemas/generator.schemaTestNode"}},
+					},
+					Required: []string{"name"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newSchemaRegistry()
+			assert.Equal(t, tt.want, r.schemaOf(tt.value))
+
+			if tt.components != nil {
+				assert.Equal(t, tt.components, r.components)
+			}
+		})
+	}
+}
+
+func TestSchemaRegistry_structFields(t *testing.T) {
+	r := newSchemaRegistry()
+	r.schemaOf(schemaTestUser{})
+
+	got := r.components["generator.schemaTestUser"]
+	assert.Equal(t, &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"id":         {Type: "string"},
+			"email":      {Type: "string", Description: "the user email"},
+			"age":        {Type: "integer", Format: "int64"},
+			"score":      {Type: "number", Format: "double"},
+			"active":     {Type: "boolean"},
+			"created_at": {Type: "string", Format: "date-time"},
+			"avatar":     {Type: "string", ContentEncoding: "base64"},
+			"labels":     {Type: "object", AdditionalProperties: &Schema{Type: "string"}},
+			"count":      {Type: "string"},
+			"extra":      {},
+			"NoTag":      {Type: "string"},
+		},
+		Required: []string{"id"},
+	}, got)
+}
+
+func TestSchemaRegistry_samePackageName(t *testing.T) {
+	r := newSchemaRegistry()
+
+	page := r.schemaOf(billingdto.Page[billingdto.User]{})
+	identity := r.schemaOf(identitydto.User{})
+
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/identity.dto.User"}, identity)
+	assert.NotContains(t, r.components, "dto.User")
+
+	// the reference registered before the collision follows the rename
+	pageName := strings.TrimPrefix(page.Ref, "#/components/schemas/")
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/billing.dto.User"}, r.components[pageName].Properties["items"].Items)
+
+	assert.Contains(t, r.components["billing.dto.User"].Properties, "account_id")
+	assert.Contains(t, r.components["identity.dto.User"].Properties, "email")
+}
@@ -0,0 +1,180 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/diegoclair/goswag/models"
+)
+
+// SecurityRequirement requires a registered security scheme, with the oauth2
+// scopes needed by the route. Several requirements are alternatives.
+type SecurityRequirement struct {
+	Scheme string
+	Scopes []string
+}
+
+// bearerDescription documents bearer schemes in the swag annotations, where
+// they can only be declared as an Authorization header apiKey.
+const bearerDescription = `Type "Bearer" followed by a space and the token.`
+
+// resolveSecurity returns a copy of the routes and groups where every route
+// carries its effective requirements: its own, or else the ones of the
+// closest group that declares some, or else the instance ones. Public routes
+// get none.
+func resolveSecurity(routes []Route, groups []Group, inherited []SecurityRequirement) ([]Route, []Group) {
+	var (
+		newRoutes []Route
+		newGroups []Group
+	)
+
+	for _, r := range routes {
+		switch {
+		case r.Public:
+			r.Security = nil
+		case len(r.Security) == 0:
+			r.Security = inherited
+		}
+		newRoutes = append(newRoutes, r)
+	}
+
+	for _, g := range groups {
+		groupSecurity := inherited
+		if len(g.Security) > 0 {
+			groupSecurity = g.Security
+		}
+
+		g.Routes, g.Groups = resolveSecurity(g.Routes, g.Groups, groupSecurity)
+		newGroups = append(newGroups, g)
+	}
+
+	return newRoutes, newGroups
+}
+
+// writeSecurity writes the @Security lines of a route.
+func writeSecurity(s *strings.Builder, security []SecurityRequirement) {
+	for _, req := range security {
+		if len(req.Scopes) == 0 {
+			s.WriteString(fmt.Sprintf("// @Security %s\n", req.Scheme))
+			continue
+		}
+
+		s.WriteString(fmt.Sprintf("// @Security %s[%s]\n", req.Scheme, strings.Join(req.Scopes, ", ")))
+	}
+}
+
+// writeSecurityDefinitions writes the swag general API info declaring the
+// security schemes. `goswag docs` passes it to swag together with the
+// general info of main.go.
+func writeSecurityDefinitions(s *strings.Builder, schemes map[string]models.SecurityScheme) {
+	if len(schemes) == 0 {
+		return
+	}
+
+	for _, name := range sortedKeys(schemes) {
+		scheme := schemes[name]
+		description := scheme.Description
+
+		switch scheme.Type {
+		case models.SecurityBasic:
+			s.WriteString(fmt.Sprintf("// @securityDefinitions.basic %s\n", name))
+		case models.SecurityBearer:
+			s.WriteString(fmt.Sprintf("// @securityDefinitions.apikey %s\n", name))
+			s.WriteString("// @in header\n")
+			s.WriteString("// @name Authorization\n")
+			if description == "" {
+				description = bearerDescription
+			}
+		case models.SecurityOAuth2:
+			s.WriteString(fmt.Sprintf("// @securityDefinitions.oauth2.%s %s\n", scheme.Flow, name))
+			addLineIfNotEmpty(s, scheme.AuthorizationURL, "// @authorizationUrl %s\n")
+			addLineIfNotEmpty(s, scheme.TokenURL, "// @tokenUrl %s\n")
+			for _, scope := range sortedKeys(scheme.Scopes) {
+				s.WriteString(fmt.Sprintf("// @scope.%s %s\n", scope, scheme.Scopes[scope]))
+			}
+		default:
+			s.WriteString(fmt.Sprintf("// @securityDefinitions.apikey %s\n", name))
+			s.WriteString(fmt.Sprintf("// @in %s\n", scheme.In))
+			s.WriteString(fmt.Sprintf("// @name %s\n", scheme.Name))
+		}
+
+		addLineIfNotEmpty(s, description, "// @description %s\n")
+	}
+
+	s.WriteString("\n")
+}
+
+// SecuritySchemeObject is an OpenAPI security scheme.
+type SecuritySchemeObject struct {
+	Type         string      `json:"type"`
+	Description  string      `json:"description,omitempty"`
+	Name         string      `json:"name,omitempty"`
+	In           string      `json:"in,omitempty"`
+	Scheme       string      `json:"scheme,omitempty"`
+	BearerFormat string      `json:"bearerFormat,omitempty"`
+	Flows        *OAuthFlows `json:"flows,omitempty"`
+}
+
+type OAuthFlows struct {
+	Implicit          *OAuthFlow `json:"implicit,omitempty"`
+	Password          *OAuthFlow `json:"password,omitempty"`
+	ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty"`
+	AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty"`
+}
+
+type OAuthFlow struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes"`
+}
+
+// openAPISecurityScheme converts a goswag security scheme to OpenAPI.
+func openAPISecurityScheme(scheme models.SecurityScheme) *SecuritySchemeObject {
+	switch scheme.Type {
+	case models.SecurityBasic:
+		return &SecuritySchemeObject{Type: "http", Scheme: "basic", Description: scheme.Description}
+	case models.SecurityBearer:
+		return &SecuritySchemeObject{Type: "http", Scheme: "bearer", BearerFormat: scheme.BearerFormat, Description: scheme.Description}
+	case models.SecurityOAuth2:
+		flow := &OAuthFlow{
+			AuthorizationURL: scheme.AuthorizationURL,
+			TokenURL:         scheme.TokenURL,
+			Scopes:           scheme.Scopes,
+		}
+		if flow.Scopes == nil {
+			flow.Scopes = map[string]string{}
+		}
+
+		flows := &OAuthFlows{}
+		switch scheme.Flow {
+		case models.OAuth2Implicit:
+			flows.Implicit = flow
+		case models.OAuth2Password:
+			flows.Password = flow
+		case models.OAuth2Application:
+			flows.ClientCredentials = flow
+		default:
+			flows.AuthorizationCode = flow
+		}
+
+		return &SecuritySchemeObject{Type: "oauth2", Flows: flows, Description: scheme.Description}
+	}
+
+	return &SecuritySchemeObject{Type: "apiKey", In: scheme.In, Name: scheme.Name, Description: scheme.Description}
+}
+
+// openAPISecurity converts the requirements of a route to OpenAPI, where
+// each entry of the list is an alternative.
+func openAPISecurity(security []SecurityRequirement) []map[string][]string {
+	var requirements []map[string][]string
+
+	for _, req := range security {
+		scopes := req.Scopes
+		if scopes == nil {
+			scopes = []string{}
+		}
+		requirements = append(requirements, map[string][]string{req.Scheme: scopes})
+	}
+
+	return requirements
+}
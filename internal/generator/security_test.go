@@ -0,0 +1,150 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/diegoclair/goswag/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSchemes = map[string]models.SecurityScheme{
+	"ApiKey": {Type: models.SecurityAPIKey, In: "header", Name: "X-API-Key", Description: "Partner key"},
+	"Basic":  {Type: models.SecurityBasic},
+	"Bearer": {Type: models.SecurityBearer, BearerFormat: "JWT"},
+	"OAuth2": {
+		Type:     models.SecurityOAuth2,
+		Flow:     models.OAuth2Application,
+		TokenURL: "https://auth.example.com/token",
+		Scopes:   map[string]string{"write": "Write access", "read": "Read access"},
+	},
+}
+
+func TestResolveSecurity(t *testing.T) {
+	instance := []SecurityRequirement{{Scheme: "Bearer"}}
+	admin := []SecurityRequirement{{Scheme: "OAuth2", Scopes: []string{"write"}}}
+
+	routes := []Route{
+		{Path: "/health", Public: true},
+		{Path: "/me"},
+		{Path: "/keys", Security: []SecurityRequirement{{Scheme: "ApiKey"}}},
+	}
+	groups := []Group{
+		{
+			GroupName: "/admin",
+			Security:  admin,
+			Routes:    []Route{{Path: "/admin/users"}, {Path: "/admin/login", Public: true, Security: admin}},
+			Groups:    []Group{{GroupName: "/reports", Routes: []Route{{Path: "/admin/reports"}}}},
+		},
+		{GroupName: "/users", Routes: []Route{{Path: "/users"}}},
+	}
+
+	gotRoutes, gotGroups := resolveSecurity(routes, groups, instance)
+
+	assert.Nil(t, gotRoutes[0].Security, "Should not require anything on public routes")
+	assert.Equal(t, instance, gotRoutes[1].Security, "Should inherit the instance security")
+	assert.Equal(t, []SecurityRequirement{{Scheme: "ApiKey"}}, gotRoutes[2].Security, "Should keep the route security")
+	assert.Equal(t, admin, gotGroups[0].Routes[0].Security, "Should inherit the group security")
+	assert.Nil(t, gotGroups[0].Routes[1].Security, "Should not require anything on public routes of a group")
+	assert.Equal(t, admin, gotGroups[0].Groups[0].Routes[0].Security, "Should inherit the security of the parent group")
+	assert.Equal(t, instance, gotGroups[1].Routes[0].Security, "Should inherit the instance security in groups without security")
+
+	assert.Nil(t, routes[1].Security, "Should not modify the given routes")
+	assert.Nil(t, groups[0].Routes[0].Security, "Should not modify the given groups")
+}
+
+func TestWriteSecurity(t *testing.T) {
+	tests := []struct {
+		name     string
+		security []SecurityRequirement
+		want     string
+	}{
+		{
+			name: "Should write nothing without requirements",
+		},
+		{
+			name:     "Should write the scheme",
+			security: []SecurityRequirement{{Scheme: "Bearer"}},
+			want:     "// @Security Bearer\n",
+		},
+		{
+			name:     "Should write the scopes and every alternative",
+			security: []SecurityRequirement{{Scheme: "OAuth2", Scopes: []string{"read", "write"}}, {Scheme: "ApiKey"}},
+			want:     "// @Security OAuth2[read, write]\n// @Security ApiKey\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &strings.Builder{}
+			writeSecurity(s, tt.security)
+			assert.Equal(t, tt.want, s.String())
+		})
+	}
+}
+
+func TestWriteSecurityDefinitions(t *testing.T) {
+	s := &strings.Builder{}
+	writeSecurityDefinitions(s, testSchemes)
+
+	assert.Equal(t, "// @securityDefinitions.apikey ApiKey\n"+
+		"// @in header\n"+
+		"// @name X-API-Key\n"+
+		"// @description Partner key\n"+
+		"// @securityDefinitions.basic Basic\n"+
+		"// @securityDefinitions.apikey Bearer\n"+
+		"// @in header\n"+
+		"// @name Authorization\n"+
+		"// @description "+bearerDescription+"\n"+
+		"// @securityDefinitions.oauth2.application OAuth2\n"+
+		"// @tokenUrl https://auth.example.com/token\n"+
+		"// @scope.read Read access\n"+
+		"// @scope.write Write access\n"+
+		"\n", s.String())
+}
+
+func TestWrite_security(t *testing.T) {
+	var b strings.Builder
+
+	_, err := Write(&b, Doc{
+		Routes: []Route{
+			{Path: "/me", Method: "GET"},
+			{Path: "/health", Method: "GET", Public: true},
+		},
+		Security:        []SecurityRequirement{{Scheme: "Basic"}},
+		SecuritySchemes: map[string]models.SecurityScheme{"Basic": {Type: models.SecurityBasic}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "// Code generated by goswag. DO NOT EDIT.\n\npackage main\n\n"+
+		"// @securityDefinitions.basic Basic\n\n"+
+		"// @Security Basic\n// @Router /me [get]\n\n"+
+		"// @Router /health [get]\n\n", b.String())
+}
+
+func TestBuildOpenAPI_security(t *testing.T) {
+	doc := BuildOpenAPI(Doc{
+		Routes: []Route{
+			{Path: "/me", Method: "GET"},
+			{Path: "/health", Method: "GET", Public: true},
+			{Path: "/reports", Method: "GET", Security: []SecurityRequirement{{Scheme: "OAuth2", Scopes: []string{"read"}}}},
+		},
+		Security:        []SecurityRequirement{{Scheme: "Bearer"}},
+		SecuritySchemes: testSchemes,
+	})
+
+	assert.Equal(t, []map[string][]string{{"Bearer": {}}}, (*doc.Paths["/me"])["get"].Security)
+	assert.Nil(t, (*doc.Paths["/health"])["get"].Security)
+	assert.Equal(t, []map[string][]string{{"OAuth2": {"read"}}}, (*doc.Paths["/reports"])["get"].Security)
+
+	assert.Equal(t, map[string]*SecuritySchemeObject{
+		"ApiKey": {Type: "apiKey", In: "header", Name: "X-API-Key", Description: "Partner key"},
+		"Basic":  {Type: "http", Scheme: "basic"},
+		"Bearer": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+		"OAuth2": {Type: "oauth2", Flows: &OAuthFlows{ClientCredentials: &OAuthFlow{
+			TokenURL: "https://auth.example.com/token",
+			Scopes:   map[string]string{"write": "Write access", "read": "Read access"},
+		}}},
+	}, doc.Components.SecuritySchemes)
+}
@@ -0,0 +1,206 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// Spec is one of the independent specs documented by a Doc. The routes of a
+// named spec are written to their own stub, in a folder named after the
+// spec, which `goswag docs` passes to swag with --instanceName.
+type Spec struct {
+	Name string // "" for the default spec
+	Doc  Doc
+}
+
+// Specs splits doc in one Doc per spec, the default one first and then the
+// named ones in declaration order. A route belongs to the spec of its closest
+// group that sets one, or else to the spec of the instance. Named specs get
+// their own info, when set with SpecInfo, and share the security schemes,
+// defaults and tags of doc. The default spec is always returned, even
+// without routes, to keep the stub every setup expects.
+func Specs(doc Doc) []Spec {
+	parts := splitSpecs(doc.Routes, doc.Groups, doc.Spec)
+
+	names := []string{""}
+	for _, name := range parts.order {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+
+	specs := make([]Spec, 0, len(names))
+	for _, name := range names {
+		specDoc := doc
+		specDoc.Routes, specDoc.Groups = nil, nil
+
+		if part, ok := parts.parts[name]; ok {
+			specDoc.Routes, specDoc.Groups = part.routes, part.groups
+		}
+
+		if info, ok := doc.SpecInfo[name]; ok && name != "" {
+			specDoc.Info = info
+		}
+
+		if name != doc.Spec {
+			// the instance tag is only declared with the instance routes
+			specDoc.Tag = nil
+		}
+
+		specs = append(specs, Spec{Name: name, Doc: specDoc})
+	}
+
+	return specs
+}
+
+type specParts struct {
+	order []string
+	parts map[string]*specPart
+}
+
+type specPart struct {
+	routes []Route
+	groups []Group
+}
+
+func (p *specParts) part(name string) *specPart {
+	if part, ok := p.parts[name]; ok {
+		return part
+	}
+
+	part := &specPart{}
+	p.parts[name] = part
+	p.order = append(p.order, name)
+
+	return part
+}
+
+// splitSpecs sorts the routes by spec. A group holding routes of several
+// specs is copied into each of them with the routes of that spec only.
+func splitSpecs(routes []Route, groups []Group, spec string) *specParts {
+	p := &specParts{parts: make(map[string]*specPart)}
+
+	for _, r := range routes {
+		part := p.part(spec)
+		part.routes = append(part.routes, r)
+	}
+
+	for _, g := range groups {
+		groupSpec := spec
+		if g.Spec != "" {
+			groupSpec = g.Spec
+		}
+
+		sub := splitSpecs(g.Routes, g.Groups, groupSpec)
+		if len(sub.order) == 0 {
+			part := p.part(groupSpec)
+			part.groups = append(part.groups, g)
+			continue
+		}
+
+		for _, name := range sub.order {
+			specGroup := g
+			specGroup.Routes, specGroup.Groups = sub.parts[name].routes, sub.parts[name].groups
+
+			part := p.part(name)
+			part.groups = append(part.groups, specGroup)
+		}
+	}
+
+	return p
+}
+
+// specPackageName returns the package clause of the stub of a named spec:
+// the spec name as a Go identifier, e.g. v1 or admin_api.
+func specPackageName(name string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			continue
+		}
+		b.WriteRune('_')
+	}
+
+	pkg := b.String()
+	if pkg == "" || !unicode.IsLetter(rune(pkg[0])) {
+		pkg = "spec_" + pkg
+	}
+
+	return pkg
+}
+
+// specsFileName returns the name of the file, next to the default stub,
+// that records the named specs written by the last run: goswag.specs for
+// goswag.go. Only the stubs of these specs are removed when they are no
+// longer declared, so the stubs other runs write in the folders of the
+// output directory are left alone.
+func specsFileName(fileName string) string {
+	return strings.TrimSuffix(fileName, filepath.Ext(fileName)) + ".specs"
+}
+
+// removeStaleSpecs removes the stubs of the named specs the last run wrote
+// that are no longer declared, and records the named specs of this run in
+// their place. The folders are removed when left empty.
+func removeStaleSpecs(outputDir, fileName string, specs []Spec, logger Logger) error {
+	declared := make(map[string]bool, len(specs))
+	var names []string
+	for _, spec := range specs {
+		declared[spec.Name] = true
+		if spec.Name != "" {
+			names = append(names, spec.Name)
+		}
+	}
+
+	record := filepath.Join(outputDir, specsFileName(fileName))
+	written, err := os.ReadFile(record)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	for _, name := range strings.Fields(string(written)) {
+		if declared[name] {
+			continue
+		}
+
+		path := filepath.Join(outputDir, name, fileName)
+		content, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if !bytes.HasPrefix(content, []byte(generatedHeader)) {
+			continue
+		}
+
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("removing stale spec %s: %w", path, err)
+		}
+		_ = os.Remove(filepath.Dir(path)) // only succeeds when it is left empty
+
+		logger.Printf("%s file removed, its spec is no longer declared", path)
+	}
+
+	if len(names) == 0 {
+		if err := os.Remove(record); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("removing %s: %w", record, err)
+		}
+		return nil
+	}
+
+	if err := os.WriteFile(record, []byte(strings.Join(names, "\n")+"\n"), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", record, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/diegoclair/goswag/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecs(t *testing.T) {
+	doc := Doc{
+		Routes: []Route{{Path: "/health"}},
+		Groups: []Group{
+			{GroupName: "/v1", Spec: "v1", Routes: []Route{{Path: "/v1/users"}}},
+			{
+				GroupName: "/api",
+				Routes:    []Route{{Path: "/api/status"}},
+				Groups: []Group{
+					{GroupName: "/v2", Spec: "v2", Routes: []Route{{Path: "/api/v2/users"}}},
+					{GroupName: "/admin", Spec: "v1", Routes: []Route{{Path: "/api/admin"}}},
+				},
+			},
+		},
+		Info:     models.Info{Title: "API", Version: "1.0"},
+		SpecInfo: map[string]models.Info{"v2": {Title: "API", Version: "2.0"}},
+		Tag:      &models.Tag{Name: "Health"},
+	}
+
+	specs := Specs(doc)
+	require.Len(t, specs, 3)
+
+	assert.Equal(t, "", specs[0].Name)
+	assert.Equal(t, []Route{{Path: "/health"}}, specs[0].Doc.Routes)
+	assert.Equal(t, []Group{{GroupName: "/api", Routes: []Route{{Path: "/api/status"}}}}, specs[0].Doc.Groups)
+	assert.Equal(t, doc.Info, specs[0].Doc.Info)
+	assert.Equal(t, doc.Tag, specs[0].Doc.Tag)
+
+	assert.Equal(t, "v1", specs[1].Name)
+	assert.Nil(t, specs[1].Doc.Routes)
+	assert.Equal(t, []Group{
+		{GroupName: "/v1", Spec: "v1", Routes: []Route{{Path: "/v1/users"}}},
+		{GroupName: "/api", Groups: []Group{{GroupName: "/admin", Spec: "v1", Routes: []Route{{Path: "/api/admin"}}}}},
+	}, specs[1].Doc.Groups)
+	assert.Equal(t, doc.Info, specs[1].Doc.Info, "Should use the instance info without a spec info")
+	assert.Nil(t, specs[1].Doc.Tag, "Should declare the instance tag with the instance routes only")
+
+	assert.Equal(t, "v2", specs[2].Name)
+	assert.Equal(t, []Group{
+		{GroupName: "/api", Groups: []Group{{GroupName: "/v2", Spec: "v2", Routes: []Route{{Path: "/api/v2/users"}}}}},
+	}, specs[2].Doc.Groups)
+	assert.Equal(t, models.Info{Title: "API", Version: "2.0"}, specs[2].Doc.Info)
+}
+
+func TestSpecPackageName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "v1", want: "v1"},
+		{name: "Admin-API", want: "admin_api"},
+		{name: "2024", want: "spec_2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, specPackageName(tt.name))
+		})
+	}
+}
+
+func TestGenerate_specs(t *testing.T) {
+	dir := t.TempDir()
+
+	err := Generate(Doc{
+		Groups: []Group{{GroupName: "/v0", Spec: "v0", Routes: []Route{{Method: "GET", Path: "/v0/users"}}}},
+	}, WithOutputDir(dir), WithLogger(&testLogger{}))
+	require.NoError(t, err)
+
+	stale := filepath.Join(dir, "v0", defaultFileName)
+	require.FileExists(t, stale)
+
+	other := filepath.Join(dir, "handwritten", defaultFileName)
+	require.NoError(t, os.MkdirAll(filepath.Dir(other), 0o755))
+	require.NoError(t, os.WriteFile(other, []byte("package handwritten\n"), 0o644))
+
+	foreign := filepath.Join(dir, "admin", defaultFileName)
+	require.NoError(t, os.MkdirAll(filepath.Dir(foreign), 0o755))
+	require.NoError(t, os.WriteFile(foreign, []byte(generatedHeader+"package admin\n"), 0o644))
+
+	err = Generate(Doc{
+		Routes:   []Route{{Method: "GET", Path: "/health"}},
+		Groups:   []Group{{GroupName: "/v1", Spec: "v1", Routes: []Route{{Method: "GET", Path: "/v1/users"}}}},
+		SpecInfo: map[string]models.Info{"v1": {Title: "Users API", Version: "1.0"}},
+	}, WithOutputDir(dir), WithLogger(&testLogger{}))
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, defaultFileName))
+	require.NoError(t, err)
+	assert.Equal(t, generatedHeader+"package main\n\n// @Router /health [get]\n\n", string(content))
+
+	content, err = os.ReadFile(filepath.Join(dir, "v1", defaultFileName))
+	require.NoError(t, err)
+	assert.Equal(t, generatedHeader+"package v1\n\n"+
+		"// @title Users API\n// @version 1.0\n\n"+
+		"// @Tags v1\n// @Router /v1/users [get]\n\n", string(content))
+
+	content, err = os.ReadFile(filepath.Join(dir, "goswag.specs"))
+	require.NoError(t, err)
+	assert.Equal(t, "v1\n", string(content))
+
+	assert.NoFileExists(t, stale, "Should remove the stubs of specs no longer declared")
+	assert.NoDirExists(t, filepath.Dir(stale))
+	assert.FileExists(t, other, "Should not remove files goswag did not write")
+	assert.FileExists(t, foreign, "Should not remove the stubs of another generation")
+
+	err = Generate(Doc{Routes: []Route{{Method: "GET", Path: "/health"}}}, WithOutputDir(dir), WithLogger(&testLogger{}))
+	require.NoError(t, err)
+
+	assert.NoFileExists(t, filepath.Join(dir, "v1", defaultFileName))
+	assert.NoFileExists(t, filepath.Join(dir, "goswag.specs"), "Should remove the record without named specs")
+	assert.FileExists(t, foreign)
+}
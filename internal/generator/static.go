@@ -0,0 +1,98 @@
+package generator
+
+import "reflect"
+
+// StaticType stands for a body whose type is only known from the source, as
+// found by `goswag docs --static`, which can't build values of user types. It
+// carries what the stub needs from the type instead of the type itself.
+type StaticType struct {
+	// Name is the type as swag expects it, e.g. dto.Page[dto.User].
+	Name string
+	// Kind is the schema kind of the type when it is a response: object,
+	// array, string, integer, number, boolean or file.
+	Kind string
+	// Elem is the type written after the kind: the element of arrays, the
+	// basic type of strings and numbers, and Name for objects.
+	Elem string
+	// Packages are the import paths of the packages the type refers to.
+	Packages []string
+	// QualifiedName and QualifiedElem are Name and Elem with the types
+	// qualified by the path of their package, the way reflect prints them:
+	// github.com/foo/dto.Page[github.com/foo/dto.User]. They are rendered
+	// instead when a package of the type shares its name with another one
+	// imported by the stub.
+	QualifiedName string
+	QualifiedElem string
+}
+
+func (t StaticType) addPackages(packagesToImport map[string]bool) {
+	for _, pkg := range t.Packages {
+		addPackage(pkg, packagesToImport)
+	}
+}
+
+// aliased reports whether a package of t is imported with an alias.
+func (t StaticType) aliased(packagesToImport map[string]bool) bool {
+	for _, pkg := range t.Packages {
+		if importAlias(pkg, packagesToImport) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// render returns name, or qualified rendered with the import aliases when a
+// package of t has one.
+func (t StaticType) render(name, qualified string, packagesToImport map[string]bool) string {
+	if qualified == "" || !t.aliased(packagesToImport) {
+		return name
+	}
+
+	expr, err := parseTypeExpr(qualified)
+	if err != nil {
+		return name
+	}
+
+	return expr.render(packagesToImport)
+}
+
+// bodyTypeName is typeName for the value of a body or of an override.
+func bodyTypeName(body any, packagesToImport map[string]bool) string {
+	if st, ok := body.(StaticType); ok {
+		st.addPackages(packagesToImport)
+		return st.render(st.Name, st.QualifiedName, packagesToImport)
+	}
+
+	return typeName(reflect.TypeOf(body), packagesToImport)
+}
+
+// bodyResponseKind is responseKind for the value of a response body.
+func bodyResponseKind(body any, packagesToImport map[string]bool) (kind, name string) {
+	if st, ok := body.(StaticType); ok {
+		st.addPackages(packagesToImport)
+		return st.Kind, st.render(st.Elem, st.QualifiedElem, packagesToImport)
+	}
+
+	return responseKind(reflect.TypeOf(body), packagesToImport)
+}
+
+// bodyRequestTypeName is requestTypeName for the value of a request body.
+func bodyRequestTypeName(body any, packagesToImport map[string]bool) string {
+	if st, ok := body.(StaticType); ok {
+		st.addPackages(packagesToImport)
+		if st.Kind == "file" {
+			return "string"
+		}
+		return st.render(st.Name, st.QualifiedName, packagesToImport)
+	}
+
+	return requestTypeName(reflect.TypeOf(body), packagesToImport)
+}
+
+// isStatic reports whether body is a StaticType, whose fields can't be
+// looked up to check overrides and examples.
+func isStatic(body any) bool {
+	_, ok := body.(StaticType)
+	return ok
+}
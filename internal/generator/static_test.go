@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/diegoclair/goswag/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite_staticTypes(t *testing.T) {
+	page := StaticType{
+		Name:     "dto.Page[dto.User]",
+		Kind:     "object",
+		Elem:     "dto.Page[dto.User]",
+		Packages: []string{"example.com/app/dto"},
+	}
+	users := StaticType{Name: "[]dto.User", Kind: "array", Elem: "dto.User", Packages: []string{"example.com/app/dto"}}
+	file := StaticType{Name: "[]byte", Kind: "file", Elem: "file"}
+
+	var b strings.Builder
+
+	_, err := Write(&b, Doc{Routes: []Route{
+		{
+			Method:        "POST",
+			Path:          "/users/search",
+			Reads:         page,
+			ReadOverrides: map[string]any{"items": users},
+			Returns: []models.ReturnType{
+				{StatusCode: 200, Body: page, OverrideStructFields: map[string]any{"items": users}},
+				{StatusCode: 201, Body: users},
+			},
+		},
+		{
+			Method:  "PUT",
+			Path:    "/avatar",
+			Reads:   file,
+			Returns: []models.ReturnType{{StatusCode: 200, Body: file}},
+		},
+	}})
+	require.NoError(t, err)
+
+	got := b.String()
+	assert.Contains(t, got, "\t_ \"example.com/app/dto\"\n")
+	assert.Contains(t, got, "// @Param request body dto.Page[dto.User]{items=[]dto.User} true \"Request\"\n")
+	assert.Contains(t, got, "// @Success 200 {object} dto.Page[dto.User]{items=[]dto.User}\n")
+	assert.Contains(t, got, "// @Success 201 {array} dto.User\n")
+	assert.Contains(t, got, "// @Param request body string true \"Request\"\n")
+	assert.Contains(t, got, "// @Success 200 {file} file\n")
+}
+
+func TestWrite_staticTypesSamePackageName(t *testing.T) {
+	billing := StaticType{
+		Name:          "[]dto.User",
+		Kind:          "array",
+		Elem:          "dto.User",
+		Packages:      []string{"example.com/app/billing/dto"},
+		QualifiedName: "[]example.com/app/billing/dto.User",
+		QualifiedElem: "example.com/app/billing/dto.User",
+	}
+	identity := StaticType{
+		Name:          "dto.User",
+		Kind:          "object",
+		Elem:          "dto.User",
+		Packages:      []string{"example.com/app/identity/dto"},
+		QualifiedName: "example.com/app/identity/dto.User",
+		QualifiedElem: "example.com/app/identity/dto.User",
+	}
+
+	var b strings.Builder
+
+	_, err := Write(&b, Doc{Routes: []Route{{
+		Method:  "POST",
+		Path:    "/users",
+		Reads:   identity,
+		Returns: []models.ReturnType{{StatusCode: 200, Body: billing}},
+	}}})
+	require.NoError(t, err)
+
+	got := b.String()
+	assert.Contains(t, got, "	billing_dto \"example.com/app/billing/dto\"\n")
+	assert.Contains(t, got, "	identity_dto \"example.com/app/identity/dto\"\n")
+	assert.Contains(t, got, "// @Param request body identity_dto.User true \"Request\"\n")
+	assert.Contains(t, got, "// @Success 200 {array} billing_dto.User\n")
+}
+
+func TestValidate_staticTypes(t *testing.T) {
+	body := StaticType{Name: "dto.User", Kind: "object", Elem: "dto.User"}
+
+	findings := Validate(Doc{Routes: []Route{{
+		Method:        "POST",
+		Path:          "/users",
+		Reads:         body,
+		ReadOverrides: map[string]any{"address.city": body},
+		ReadExamples:  []any{map[string]any{"nick": "ann"}},
+		Returns:       []models.ReturnType{{StatusCode: 201, Body: body, OverrideStructFields: map[string]any{"data": body}}},
+	}}})
+
+	assert.Empty(t, findings, "the fields of a type known from the source only can't be checked")
+}
@@ -0,0 +1,208 @@
+package generator
+
+import (
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var fileHeaderType = reflect.TypeOf(&multipart.FileHeader{})
+
+// StructParams are the params declared by the tags of a struct, by location.
+type StructParams struct {
+	Path   []Param
+	Query  []Param
+	Header []Param
+	Form   []Param
+}
+
+// ParamsOf derives the params of a struct from the tags echo and gin bind
+// with: `param` and `uri` for the path, `query`, `header` and `form`. Like
+// gin's default binding, `form` fields are read from the query string when
+// method has no request body, and from the form body otherwise.
+//
+// A `required` rule in the binding or validate tags marks a param as
+// required, and the swag tags enums, default, example, format, minimum,
+// maximum, minLength and maxLength fill its attributes.
+func ParamsOf(v any, method string) StructParams {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var params StructParams
+	if t == nil || t.Kind() != reflect.Struct {
+		return params
+	}
+
+	formIsQuery := method == http.MethodGet || method == http.MethodHead ||
+		method == http.MethodDelete || method == http.MethodOptions
+
+	params.collect(t, formIsQuery)
+
+	return params
+}
+
+func (ps *StructParams) collect(t reflect.Type, formIsQuery bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		ft := field.Type
+		for ft.Kind() == reflect.Pointer && ft != fileHeaderType {
+			ft = ft.Elem()
+		}
+
+		locations := []struct {
+			tag  string
+			list *[]Param
+		}{
+			{"param", &ps.Path},
+			{"uri", &ps.Path},
+			{"query", &ps.Query},
+			{"header", &ps.Header},
+			{"form", &ps.Form},
+		}
+		if formIsQuery {
+			locations[4].list = &ps.Query
+		}
+
+		tagged := false
+		added := make(map[*[]Param]bool)
+
+		for _, loc := range locations {
+			name, _, _ := strings.Cut(field.Tag.Get(loc.tag), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+
+			tagged = true
+			if added[loc.list] {
+				// query and form may both point at the query string
+				continue
+			}
+			added[loc.list] = true
+
+			param := fieldParam(name, field, ft)
+			if loc.list == &ps.Path {
+				param.Required = true // a route can't match without its path params
+			}
+
+			*loc.list = append(*loc.list, param)
+		}
+
+		if !tagged && field.Anonymous && ft.Kind() == reflect.Struct {
+			ps.collect(ft, formIsQuery)
+		}
+	}
+}
+
+// fieldParam builds the param of a struct field. Slices become arrays of
+// repeated values (?tags=a&tags=b), as echo and gin bind them.
+func fieldParam(name string, field reflect.StructField, ft reflect.Type) Param {
+	param := Param{
+		Name:        name,
+		Description: field.Tag.Get("description"),
+		ParamType:   fieldParamType(ft),
+		Required:    isRequiredField(field),
+	}
+
+	if ft == timeType {
+		param.Format = "date-time"
+	}
+
+	if (ft.Kind() == reflect.Slice || ft.Kind() == reflect.Array) && param.ParamType != FileParamType {
+		param.Items = param.ParamType
+		param.ParamType = ArrayParamType
+		param.CollectionFormat = "multi"
+	}
+
+	valueType := param.ParamType
+	if param.Items != "" {
+		valueType = param.Items
+	}
+
+	if enums := field.Tag.Get("enums"); enums != "" {
+		for _, e := range strings.Split(enums, ",") {
+			param.Enum = append(param.Enum, tagValue(valueType, strings.TrimSpace(e)))
+		}
+	}
+	if def, ok := field.Tag.Lookup("default"); ok {
+		param.Default = tagValue(valueType, def)
+	}
+	if example, ok := field.Tag.Lookup("example"); ok {
+		param.Example = tagValue(valueType, example)
+	}
+	if format := field.Tag.Get("format"); format != "" {
+		param.Format = format
+	}
+	if f, err := strconv.ParseFloat(field.Tag.Get("minimum"), 64); err == nil {
+		param.Minimum = &f
+	}
+	if f, err := strconv.ParseFloat(field.Tag.Get("maximum"), 64); err == nil {
+		param.Maximum = &f
+	}
+	if n, err := strconv.Atoi(field.Tag.Get("minLength")); err == nil {
+		param.MinLength = &n
+	}
+	if n, err := strconv.Atoi(field.Tag.Get("maxLength")); err == nil {
+		param.MaxLength = &n
+	}
+
+	return param
+}
+
+// fieldParamType maps the type of a struct field to a goswag param data
+// type. For slices it returns the data type of the elements.
+func fieldParamType(t reflect.Type) string {
+	if t == fileHeaderType || (t.Kind() == reflect.Slice && t.Elem() == fileHeaderType) {
+		return FileParamType
+	}
+
+	if t == timeType {
+		return "string"
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		elem := t.Elem()
+		for elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+		return fieldParamType(elem)
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	}
+
+	return "string"
+}
+
+// tagValue converts a tag value to the Go type matching the param data
+// type, so enums and defaults are encoded as numbers or booleans when needed.
+func tagValue(paramType, value string) any {
+	switch paramType {
+	case "integer":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+
+	return value
+}
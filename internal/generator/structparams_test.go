@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/diegoclair/goswag/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type listOrdersParams struct {
+	TenantID  string    `param:"tenant_id" description:"Tenant"`
+	OrderID   int64     `uri:"order_id"`
+	Status    []string  `query:"status" enums:"open,paid"`
+	Limit     *int      `query:"limit" default:"20" minimum:"1" maximum:"100"`
+	Since     time.Time `query:"since"`
+	Sort      string    `form:"sort" enums:"asc,desc" example:"asc"`
+	Both      string    `query:"both" form:"both"`
+	RequestID string    `header:"X-Request-Id" validate:"required" format:"uuid"`
+	Token     *string   `header:"X-Token" binding:"required,min=10" minLength:"10" maxLength:"64"`
+	Paid      bool      `query:"paid" default:"true"`
+	Ignored   string    `query:"-"`
+	Untagged  string
+}
+
+func TestParamsOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   any
+		method string
+		want   StructParams
+	}{
+		{
+			name:   "Should read every location, with form fields in the query for GET",
+			data:   &listOrdersParams{},
+			method: http.MethodGet,
+			want: StructParams{
+				Path: []Param{
+					{Name: "tenant_id", Description: "Tenant", ParamType: "string", Required: true},
+					{Name: "order_id", ParamType: "integer", Required: true},
+				},
+				Query: []Param{
+					{Name: "status", ParamType: ArrayParamType, ParamAttributes: models.ParamAttributes{
+						Items: "string", CollectionFormat: "multi", Enum: []any{"open", "paid"},
+					}},
+					{Name: "limit", ParamType: "integer", ParamAttributes: models.ParamAttributes{
+						Default: int64(20), Minimum: ptr(1.0), Maximum: ptr(100.0),
+					}},
+					{Name: "since", ParamType: "string", ParamAttributes: models.ParamAttributes{Format: "date-time"}},
+					{Name: "sort", ParamType: "string", ParamAttributes: models.ParamAttributes{
+						Enum: []any{"asc", "desc"}, Example: "asc",
+					}},
+					{Name: "both", ParamType: "string"},
+					{Name: "paid", ParamType: "boolean", ParamAttributes: models.ParamAttributes{Default: true}},
+				},
+				Header: []Param{
+					{Name: "X-Request-Id", ParamType: "string", Required: true, ParamAttributes: models.ParamAttributes{Format: "uuid"}},
+					{Name: "X-Token", ParamType: "string", Required: true, ParamAttributes: models.ParamAttributes{
+						MinLength: ptr(10), MaxLength: ptr(64),
+					}},
+				},
+			},
+		},
+		{
+			name: "Should read form fields from the body for POST",
+			data: struct {
+				Sort string `form:"sort"`
+				Both string `query:"both" form:"both"`
+			}{},
+			method: http.MethodPost,
+			want: StructParams{
+				Query: []Param{{Name: "both", ParamType: "string"}},
+				Form: []Param{
+					{Name: "sort", ParamType: "string"},
+					{Name: "both", ParamType: "string"},
+				},
+			},
+		},
+		{
+			name:   "Should return no params for non struct values",
+			data:   []string{},
+			method: http.MethodGet,
+			want:   StructParams{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParamsOf(tt.data, tt.method))
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/diegoclair/goswag/models"
+)
+
+// DefaultTagName is the default TagNamer: the prefixes of the group joined
+// and without the leading and trailing slashes, e.g. "api/users" for a
+// "/users" group inside an "/api" one.
+func DefaultTagName(prefixes []string) string {
+	return strings.Trim(path.Join(prefixes...), "/")
+}
+
+// applyTags returns a copy of the routes and groups where the routes without
+// tags get the tag of their group: the declared one or, for the groups, the
+// one named by namer from the prefixes. The instance routes only get the
+// declared tag of the instance.
+func applyTags(routes []Route, groups []Group, tag *models.Tag, namer models.TagNamer) ([]Route, []Group) {
+	if namer == nil {
+		namer = DefaultTagName
+	}
+
+	name := ""
+	if tag != nil {
+		name = tag.Name
+	}
+
+	return applyGroupTags(routes, groups, nil, name, namer)
+}
+
+func applyGroupTags(routes []Route, groups []Group, prefixes []string, name string, namer models.TagNamer) ([]Route, []Group) {
+	var (
+		newRoutes []Route
+		newGroups []Group
+	)
+
+	for _, r := range routes {
+		if len(r.Tags) == 0 && name != "" {
+			r.Tags = []string{name}
+		}
+		newRoutes = append(newRoutes, r)
+	}
+
+	for _, g := range groups {
+		groupPrefixes := append(append([]string{}, prefixes...), g.GroupName)
+
+		groupName := namer(groupPrefixes)
+		if g.Tag != nil {
+			groupName = g.Tag.Name
+		}
+
+		g.Routes, g.Groups = applyGroupTags(g.Routes, g.Groups, groupPrefixes, groupName, namer)
+		newGroups = append(newGroups, g)
+	}
+
+	return newRoutes, newGroups
+}
+
+// declaredTags returns the tags declared on the instance and on the groups,
+// in registration order. A tag declared twice keeps its first declaration.
+func declaredTags(doc Doc) []models.Tag {
+	var (
+		tags []models.Tag
+		seen = make(map[string]bool)
+	)
+
+	add := func(tag *models.Tag) {
+		if tag == nil || tag.Name == "" || seen[tag.Name] {
+			return
+		}
+		seen[tag.Name] = true
+		tags = append(tags, *tag)
+	}
+
+	add(doc.Tag)
+
+	var walk func(groups []Group)
+	walk = func(groups []Group) {
+		for _, g := range groups {
+			add(g.Tag)
+			walk(g.Groups)
+		}
+	}
+	walk(doc.Groups)
+
+	return tags
+}
+
+// writeTags writes the swag general API info declaring the tags.
+func writeTags(s *strings.Builder, tags []models.Tag) {
+	if len(tags) == 0 {
+		return
+	}
+
+	for _, tag := range tags {
+		s.WriteString(fmt.Sprintf("// @tag.name %s\n", tag.Name))
+		addLineIfNotEmpty(s, tag.Description, "// @tag.description %s\n")
+		addLineIfNotEmpty(s, tag.ExternalDocs.URL, "// @tag.docs.url %s\n")
+		addLineIfNotEmpty(s, tag.ExternalDocs.Description, "// @tag.docs.description %s\n")
+	}
+
+	s.WriteString("\n")
+}
+
+type OpenAPITag struct {
+	Name         string        `json:"name"`
+	Description  string        `json:"description,omitempty"`
+	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty"`
+}
+
+// openAPITags converts the declared tags to OpenAPI.
+func openAPITags(tags []models.Tag) []OpenAPITag {
+	var openAPITags []OpenAPITag
+	for _, tag := range tags {
+		openAPITags = append(openAPITags, OpenAPITag{
+			Name:         tag.Name,
+			Description:  tag.Description,
+			ExternalDocs: openAPIExternalDocs(tag.ExternalDocs),
+		})
+	}
+
+	return openAPITags
+}
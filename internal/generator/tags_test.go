@@ -0,0 +1,116 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/diegoclair/goswag/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultTagName(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefixes []string
+		want     string
+	}{
+		{name: "Should strip the slashes", prefixes: []string{"/users/"}, want: "users"},
+		{name: "Should join nested prefixes", prefixes: []string{"/api", "/v1", "users"}, want: "api/v1/users"},
+		{name: "Should return empty for the root group", prefixes: []string{"/"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DefaultTagName(tt.prefixes))
+		})
+	}
+}
+
+func TestApplyTags(t *testing.T) {
+	routes := []Route{{Path: "/health"}}
+	groups := []Group{
+		{
+			GroupName: "/api",
+			Routes:    []Route{{Path: "/api/status"}},
+			Groups: []Group{
+				{GroupName: "/users", Routes: []Route{{Path: "/api/users"}, {Path: "/api/users/me", Tags: []string{"me"}}}},
+				{GroupName: "/admin", Tag: &models.Tag{Name: "Admin"}, Routes: []Route{{Path: "/api/admin"}}},
+			},
+		},
+	}
+
+	t.Run("Should name the group tags with the default namer", func(t *testing.T) {
+		gotRoutes, gotGroups := applyTags(routes, groups, nil, nil)
+
+		assert.Nil(t, gotRoutes[0].Tags)
+		assert.Equal(t, []string{"api"}, gotGroups[0].Routes[0].Tags)
+		assert.Equal(t, []string{"api/users"}, gotGroups[0].Groups[0].Routes[0].Tags)
+		assert.Equal(t, []string{"me"}, gotGroups[0].Groups[0].Routes[1].Tags, "Should keep the route tags")
+		assert.Equal(t, []string{"Admin"}, gotGroups[0].Groups[1].Routes[0].Tags, "Should use the declared tag")
+
+		assert.Nil(t, groups[0].Routes[0].Tags, "Should not modify the given groups")
+	})
+
+	t.Run("Should use the given namer and the instance tag", func(t *testing.T) {
+		namer := func(prefixes []string) string {
+			return strings.TrimPrefix(prefixes[len(prefixes)-1], "/")
+		}
+
+		gotRoutes, gotGroups := applyTags(routes, groups, &models.Tag{Name: "Health"}, namer)
+
+		assert.Equal(t, []string{"Health"}, gotRoutes[0].Tags)
+		assert.Equal(t, []string{"users"}, gotGroups[0].Groups[0].Routes[0].Tags)
+	})
+}
+
+func TestDeclaredTags(t *testing.T) {
+	doc := Doc{
+		Tag: &models.Tag{Name: "Health"},
+		Groups: []Group{
+			{Tag: &models.Tag{Name: "Users", Description: "Manage the users"}, Groups: []Group{{Tag: &models.Tag{Name: "Admin"}}}},
+			{Tag: &models.Tag{Name: "Users", Description: "Duplicated"}},
+			{GroupName: "/orders"},
+		},
+	}
+
+	assert.Equal(t, []models.Tag{
+		{Name: "Health"},
+		{Name: "Users", Description: "Manage the users"},
+		{Name: "Admin"},
+	}, declaredTags(doc))
+}
+
+func TestWriteTags(t *testing.T) {
+	s := &strings.Builder{}
+	writeTags(s, []models.Tag{
+		{Name: "Users", Description: "Manage the users", ExternalDocs: models.ExternalDocs{Description: "Guide", URL: "https://example.com/users"}},
+		{Name: "Admin"},
+	})
+
+	assert.Equal(t, "// @tag.name Users\n"+
+		"// @tag.description Manage the users\n"+
+		"// @tag.docs.url https://example.com/users\n"+
+		"// @tag.docs.description Guide\n"+
+		"// @tag.name Admin\n"+
+		"\n", s.String())
+}
+
+func TestBuildOpenAPI_tags(t *testing.T) {
+	doc := BuildOpenAPI(Doc{Groups: []Group{
+		{
+			GroupName: "/api",
+			Groups: []Group{{
+				GroupName: "/users",
+				Tag:       &models.Tag{Name: "Users", ExternalDocs: models.ExternalDocs{URL: "https://example.com/users"}},
+				Routes:    []Route{{Path: "/api/users", Method: "GET"}},
+			}},
+			Routes: []Route{{Path: "/api/status", Method: "GET"}},
+		},
+	}})
+
+	assert.Equal(t, []string{"api"}, (*doc.Paths["/api/status"])["get"].Tags)
+	assert.Equal(t, []string{"Users"}, (*doc.Paths["/api/users"])["get"].Tags)
+	assert.Equal(t, []OpenAPITag{
+		{Name: "Users", ExternalDocs: &ExternalDocs{URL: "https://example.com/users"}},
+	}, doc.Tags)
+}
@@ -0,0 +1,11 @@
+// Package dto shares its name with identity/dto, to test the types of
+// packages with the same name.
+package dto
+
+type User struct {
+	AccountID string `json:"account_id"`
+}
+
+type Page[T any] struct {
+	Items []T `json:"items"`
+}
@@ -0,0 +1,7 @@
+// Package dto shares its name with billing/dto, to test the types of
+// packages with the same name.
+package dto
+
+type User struct {
+	Email string `json:"email"`
+}
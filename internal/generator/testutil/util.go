@@ -11,3 +11,16 @@ type TestGeneric struct {
 type OverrideStruct struct {
 	Body any ` json:"body" `
 }
+
+type Page[T any, C any] struct {
+	Items []T
+	Next  C
+}
+
+type Envelope[T any] struct {
+	Data T
+}
+
+type Cursor struct {
+	Token string
+}
@@ -0,0 +1,486 @@
+package generator
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// majorVersionSuffix matches the /vN and .vN suffixes of module paths
+// (github.com/foo/bar/v2, gopkg.in/yaml.v3) that are not part of the
+// package name.
+var majorVersionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// typeName renders t the way swag expects it in annotations, e.g.
+// pkg.Page[pkg.User,other.Cursor], and adds every package referenced by t,
+// including the ones of its type arguments, to packagesToImport.
+func typeName(t reflect.Type, packagesToImport map[string]bool) string {
+	if t == nil {
+		return "interface{}"
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Name() != "" {
+		if t.PkgPath() == "" {
+			return t.Name() // predeclared: int, string, error...
+		}
+
+		addPackage(t.PkgPath(), packagesToImport)
+
+		// reflect only exposes the type arguments of an instantiated generic
+		// as text, in its Name: Page[github.com/foo/dto.User,int]
+		base, args, _ := strings.Cut(t.Name(), "[")
+
+		// the package name, unlike the last element of its path, is only
+		// available through String(): "dto.Page[...]"
+		pkgName, _, _ := strings.Cut(t.String(), ".")
+
+		name := qualifier(t.PkgPath(), pkgName, packagesToImport) + "." + base
+		if args == "" {
+			return name
+		}
+
+		expr, err := parseTypeExpr("_[" + args)
+		if err != nil {
+			return t.String()
+		}
+
+		return name + expr.renderArgs(packagesToImport)
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return "[]" + typeName(t.Elem(), packagesToImport)
+	case reflect.Map:
+		return "map[" + typeName(t.Key(), packagesToImport) + "]" + typeName(t.Elem(), packagesToImport)
+	case reflect.Interface:
+		return "interface{}"
+	}
+
+	return t.String()
+}
+
+// responseKind returns the swag schema kind of a response body, {object},
+// {array}, {string}, {integer}, {number}, {boolean} or {file}, along with the
+// type written after it.
+func responseKind(t reflect.Type, packagesToImport map[string]bool) (kind, name string) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil {
+		return "object", "interface{}"
+	}
+
+	if isBytes(t) {
+		return "file", "file"
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return "array", typeName(t.Elem(), packagesToImport)
+	case reflect.String:
+		return "string", "string"
+	case reflect.Bool:
+		return "boolean", "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer", t.Kind().String()
+	case reflect.Float32, reflect.Float64:
+		return "number", t.Kind().String()
+	}
+
+	return "object", typeName(t, packagesToImport)
+}
+
+// requestTypeName is typeName for request bodies: swag has no file kind for
+// body params, so raw bytes are documented as a string.
+func requestTypeName(t reflect.Type, packagesToImport map[string]bool) string {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t != nil && isBytes(t) {
+		return "string"
+	}
+
+	return typeName(t, packagesToImport)
+}
+
+// isBytes reports whether t is a []byte, which is how handlers usually
+// return file downloads and raw payloads.
+func isBytes(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+// addPackage registers a package to import in the stub. The main package
+// can't be imported; its types are found by swag because the stub lives in it.
+func addPackage(pkgPath string, packagesToImport map[string]bool) {
+	if pkgPath == "" || pkgPath == "main" || packagesToImport == nil {
+		return
+	}
+
+	packagesToImport[pkgPath] = true
+}
+
+// qualifier returns what the types of the package at pkgPath, named name,
+// are qualified with in the stub: its name or, when another imported package
+// has the same name, its import alias.
+func qualifier(pkgPath, name string, packagesToImport map[string]bool) string {
+	if alias := importAlias(pkgPath, packagesToImport); alias != "" {
+		return alias
+	}
+
+	return name
+}
+
+// importAlias returns the alias the package at pkgPath is imported with in
+// the stub, or "" when a blank import is enough. swag resolves pkg.Type by
+// the name of the imported packages, so packages sharing a name, like
+// billing/dto and identity/dto, get an alias made of the last elements of
+// their path that tell them apart: billing_dto and identity_dto.
+//
+// The aliases depend on every package imported by the stub, which is only
+// known once the whole stub has been rendered: it is rendered twice.
+func importAlias(pkgPath string, packagesToImport map[string]bool) string {
+	if !packagesToImport[pkgPath] {
+		return ""
+	}
+
+	name := packageNameFromPath(pkgPath)
+
+	var others []string
+	for p := range packagesToImport {
+		if p != pkgPath && packageNameFromPath(p) == name {
+			others = append(others, p)
+		}
+	}
+
+	if len(others) == 0 {
+		return ""
+	}
+
+	parents := parentSegments(pkgPath)
+	for n := 1; n <= len(parents); n++ {
+		alias := aliasOf(parents, n, name)
+
+		unique := true
+		for _, other := range others {
+			if aliasOf(parentSegments(other), n, name) == alias {
+				unique = false
+				break
+			}
+		}
+
+		if unique {
+			return alias
+		}
+	}
+
+	return identifier(pkgPath)
+}
+
+var invalidAliasChars = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// parentSegments returns the elements of pkgPath before the one naming the
+// package, the major version suffix left out: [github.com foo billing] for
+// github.com/foo/billing/dto/v2.
+func parentSegments(pkgPath string) []string {
+	segments := strings.Split(pkgPath, "/")
+	if len(segments) > 1 && majorVersionSuffix.MatchString(segments[len(segments)-1]) {
+		segments = segments[:len(segments)-1]
+	}
+
+	return segments[:len(segments)-1]
+}
+
+// aliasOf joins the last n parents and the package name into an identifier.
+func aliasOf(parents []string, n int, name string) string {
+	if n > len(parents) {
+		n = len(parents)
+	}
+
+	alias := strings.Join(append(append([]string{}, parents[len(parents)-n:]...), name), "_")
+
+	return identifier(alias)
+}
+
+// identifier replaces what can't be part of a Go identifier in s.
+func identifier(s string) string {
+	s = invalidAliasChars.ReplaceAllString(s, "_")
+	if s != "" && s[0] >= '0' && s[0] <= '9' {
+		s = "_" + s
+	}
+
+	return s
+}
+
+// packageNameFromPath guesses a package name from its import path. It is
+// only used for type arguments, whose package name reflect doesn't expose.
+func packageNameFromPath(pkgPath string) string {
+	segments := strings.Split(pkgPath, "/")
+	name := segments[len(segments)-1]
+
+	if majorVersionSuffix.MatchString(name) && len(segments) > 1 {
+		name = segments[len(segments)-2]
+	}
+
+	if base, suffix, found := strings.Cut(name, "."); found && majorVersionSuffix.MatchString(suffix) {
+		name = base
+	}
+
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// schemaName returns the components/schemas key for a named type. Generic
+// instances follow swag's naming, e.g. dto.Page-dto_User-dto_Cursor, so the
+// native spec and the swag one agree on component names.
+func schemaName(t reflect.Type) string {
+	name := typeName(t, nil)
+
+	base, args, found := strings.Cut(name, "[")
+	if !found {
+		return invalidSchemaNameChars.ReplaceAllString(name, "_")
+	}
+
+	expr, err := parseTypeExpr("_[" + args)
+	if err != nil {
+		return invalidSchemaNameChars.ReplaceAllString(name, "_")
+	}
+
+	return invalidSchemaNameChars.ReplaceAllString(base+expr.schemaArgs(), "_")
+}
+
+// schemaArgs renders the type arguments the way swag suffixes generic
+// schema names: one -token per argument, with dots replaced by underscores.
+func (e *typeExpr) schemaArgs() string {
+	var s strings.Builder
+	for _, a := range e.args {
+		s.WriteString("-" + a.schemaToken())
+	}
+
+	return s.String()
+}
+
+func (e *typeExpr) schemaToken() string {
+	switch e.kind {
+	case exprSlice:
+		return "array_" + e.elem.schemaToken()
+	case exprPointer:
+		return e.elem.schemaToken()
+	case exprMap:
+		return "map_" + e.key.schemaToken() + "_" + e.elem.schemaToken()
+	case exprOpaque:
+		return "interface"
+	}
+
+	name := e.name
+	if e.pkgPath != "" {
+		name = packageNameFromPath(e.pkgPath) + "_" + e.name
+	}
+
+	return name + e.schemaArgs()
+}
+
+type typeExprKind int
+
+const (
+	exprNamed typeExprKind = iota
+	exprSlice
+	exprPointer
+	exprMap
+	exprOpaque // interfaces, anonymous structs, funcs, channels
+)
+
+// typeExpr is a type parsed from its reflect string representation, where
+// named types are qualified by their full package path.
+type typeExpr struct {
+	kind    typeExprKind
+	pkgPath string
+	name    string
+	args    []*typeExpr
+	key     *typeExpr
+	elem    *typeExpr
+}
+
+// render writes the expression with package names instead of paths.
+func (e *typeExpr) render(packagesToImport map[string]bool) string {
+	switch e.kind {
+	case exprSlice:
+		return "[]" + e.elem.render(packagesToImport)
+	case exprPointer:
+		return e.elem.render(packagesToImport)
+	case exprMap:
+		return "map[" + e.key.render(packagesToImport) + "]" + e.elem.render(packagesToImport)
+	case exprOpaque:
+		return "interface{}"
+	}
+
+	name := e.name
+	if e.pkgPath != "" {
+		addPackage(e.pkgPath, packagesToImport)
+		name = qualifier(e.pkgPath, packageNameFromPath(e.pkgPath), packagesToImport) + "." + e.name
+	}
+
+	return name + e.renderArgs(packagesToImport)
+}
+
+func (e *typeExpr) renderArgs(packagesToImport map[string]bool) string {
+	if len(e.args) == 0 {
+		return ""
+	}
+
+	args := make([]string, 0, len(e.args))
+	for _, a := range e.args {
+		args = append(args, a.render(packagesToImport))
+	}
+
+	return "[" + strings.Join(args, ",") + "]"
+}
+
+type typeParseError struct {
+	input string
+}
+
+func (e *typeParseError) Error() string {
+	return "goswag: can't parse type " + e.input
+}
+
+// parseTypeExpr parses a type as printed by reflect.
+func parseTypeExpr(s string) (*typeExpr, error) {
+	p := &typeParser{s: s}
+
+	e := p.parse()
+	if p.err || p.pos != len(p.s) {
+		return nil, &typeParseError{input: s}
+	}
+
+	return e, nil
+}
+
+type typeParser struct {
+	s   string
+	pos int
+	err bool
+}
+
+func (p *typeParser) rest() string {
+	return p.s[p.pos:]
+}
+
+func (p *typeParser) parse() *typeExpr {
+	if p.err || p.pos >= len(p.s) {
+		p.err = true
+		return &typeExpr{kind: exprOpaque}
+	}
+
+	rest := p.rest()
+
+	switch {
+	case strings.HasPrefix(rest, "[]"):
+		p.pos += 2
+		return &typeExpr{kind: exprSlice, elem: p.parse()}
+
+	case rest[0] == '[': // array, [4]T
+		end := strings.IndexByte(rest, ']')
+		if end < 0 {
+			p.err = true
+			return &typeExpr{kind: exprOpaque}
+		}
+		p.pos += end + 1
+		return &typeExpr{kind: exprSlice, elem: p.parse()}
+
+	case rest[0] == '*':
+		p.pos++
+		return &typeExpr{kind: exprPointer, elem: p.parse()}
+
+	case strings.HasPrefix(rest, "map["):
+		p.pos += len("map[")
+		key := p.parse()
+		if !p.consume(']') {
+			return &typeExpr{kind: exprOpaque}
+		}
+		return &typeExpr{kind: exprMap, key: key, elem: p.parse()}
+
+	case strings.HasPrefix(rest, "interface {"), strings.HasPrefix(rest, "struct {"),
+		strings.HasPrefix(rest, "func("), strings.HasPrefix(rest, "chan "), strings.HasPrefix(rest, "<-chan "):
+		p.skipOpaque()
+		return &typeExpr{kind: exprOpaque}
+	}
+
+	start := p.pos
+	for p.pos < len(p.s) && !strings.ContainsRune("[],", rune(p.s[p.pos])) {
+		p.pos++
+	}
+
+	qualified := p.s[start:p.pos]
+	e := &typeExpr{kind: exprNamed, name: qualified}
+
+	// the package path may contain dots (github.com), the type name can't
+	if dot := strings.LastIndexByte(qualified, '.'); dot >= 0 {
+		e.pkgPath, e.name = qualified[:dot], qualified[dot+1:]
+	}
+
+	if p.pos < len(p.s) && p.s[p.pos] == '[' {
+		p.pos++
+		for {
+			e.args = append(e.args, p.parse())
+			if p.err || p.pos >= len(p.s) {
+				p.err = true
+				break
+			}
+			if p.s[p.pos] == ']' {
+				p.pos++
+				break
+			}
+			if !p.consume(',') {
+				break
+			}
+		}
+	}
+
+	return e
+}
+
+func (p *typeParser) consume(c byte) bool {
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+
+	p.err = true
+	return false
+}
+
+// skipOpaque moves past a type whose content goswag doesn't need: it stops
+// at the first ',' or ']' outside of brackets, braces and quoted tags.
+func (p *typeParser) skipOpaque() {
+	depth := 0
+	for p.pos < len(p.s) {
+		switch c := p.s[p.pos]; c {
+		case '"':
+			p.pos++
+			for p.pos < len(p.s) && p.s[p.pos] != '"' {
+				if p.s[p.pos] == '\\' {
+					p.pos++
+				}
+				p.pos++
+			}
+		case '(', '[', '{':
+			depth++
+		case ')', '}':
+			depth--
+		case ']', ',':
+			if depth == 0 {
+				return
+			}
+			if c == ']' {
+				depth--
+			}
+		}
+		p.pos++
+	}
+}
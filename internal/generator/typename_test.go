@@ -0,0 +1,306 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/diegoclair/goswag/internal/generator/testutil"
+	billingdto "github.com/diegoclair/goswag/internal/generator/testutil/billing/dto"
+	identitydto "github.com/diegoclair/goswag/internal/generator/testutil/identity/dto"
+	"github.com/diegoclair/goswag/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testutilPkg = "github.com/diegoclair/goswag/internal/generator/testutil"
+
+func TestTypeName(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         any
+		expected     string
+		expectedPkgs map[string]bool
+	}{
+		{
+			name:         "Should render a plain struct",
+			body:         models.ReturnType{},
+			expected:     "models.ReturnType",
+			expectedPkgs: map[string]bool{"github.com/diegoclair/goswag/models": true},
+		},
+		{
+			name:         "Should not render the pointer of a struct",
+			body:         &models.ReturnType{},
+			expected:     "models.ReturnType",
+			expectedPkgs: map[string]bool{"github.com/diegoclair/goswag/models": true},
+		},
+		{
+			name:         "Should render a generic with a primitive argument",
+			body:         testutil.StructGeneric[int]{},
+			expected:     "testutil.StructGeneric[int]",
+			expectedPkgs: map[string]bool{testutilPkg: true},
+		},
+		{
+			name:         "Should render a generic with a slice argument",
+			body:         &testutil.StructGeneric[[]testutil.TestGeneric]{},
+			expected:     "testutil.StructGeneric[[]testutil.TestGeneric]",
+			expectedPkgs: map[string]bool{testutilPkg: true},
+		},
+		{
+			name:         "Should render every type parameter",
+			body:         testutil.Page[testutil.TestGeneric, testutil.Cursor]{},
+			expected:     "testutil.Page[testutil.TestGeneric,testutil.Cursor]",
+			expectedPkgs: map[string]bool{testutilPkg: true},
+		},
+		{
+			name:         "Should render nested generics",
+			body:         testutil.Envelope[testutil.Page[testutil.TestGeneric, *testutil.Cursor]]{},
+			expected:     "testutil.Envelope[testutil.Page[testutil.TestGeneric,testutil.Cursor]]",
+			expectedPkgs: map[string]bool{testutilPkg: true},
+		},
+		{
+			name:     "Should import the packages of the type arguments",
+			body:     testutil.StructGeneric[map[string]models.ReturnType]{},
+			expected: "testutil.StructGeneric[map[string]models.ReturnType]",
+			expectedPkgs: map[string]bool{
+				testutilPkg:                           true,
+				"github.com/diegoclair/goswag/models": true,
+			},
+		},
+		{
+			name:         "Should render maps and slices of generics",
+			body:         map[string][]testutil.StructGeneric[testutil.TestGeneric]{},
+			expected:     "map[string][]testutil.StructGeneric[testutil.TestGeneric]",
+			expectedPkgs: map[string]bool{testutilPkg: true},
+		},
+		{
+			name:         "Should render interface arguments",
+			body:         testutil.StructGeneric[any]{},
+			expected:     "testutil.StructGeneric[interface{}]",
+			expectedPkgs: map[string]bool{testutilPkg: true},
+		},
+		{
+			name:         "Should not import anything for predeclared types",
+			body:         "",
+			expected:     "string",
+			expectedPkgs: map[string]bool{},
+		},
+		{
+			name:         "Should render a nil body as an interface",
+			body:         nil,
+			expected:     "interface{}",
+			expectedPkgs: map[string]bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkgs := make(map[string]bool)
+			assert.Equal(t, tt.expected, typeName(reflect.TypeOf(tt.body), pkgs))
+			assert.Equal(t, tt.expectedPkgs, pkgs)
+		})
+	}
+}
+
+func TestAddPackage(t *testing.T) {
+	tests := []struct {
+		name         string
+		pkgPath      string
+		initialPkgs  map[string]bool
+		expectedPkgs map[string]bool
+	}{
+		{
+			name:         "Should add the package",
+			pkgPath:      "github.com/diegoclair/goswag/models",
+			initialPkgs:  map[string]bool{},
+			expectedPkgs: map[string]bool{"github.com/diegoclair/goswag/models": true},
+		},
+		{
+			name:         "Should not duplicate an existing package",
+			pkgPath:      "github.com/diegoclair/goswag/models",
+			initialPkgs:  map[string]bool{"github.com/diegoclair/goswag/models": true},
+			expectedPkgs: map[string]bool{"github.com/diegoclair/goswag/models": true},
+		},
+		{
+			name:         "Should not add the main package",
+			pkgPath:      "main",
+			initialPkgs:  map[string]bool{},
+			expectedPkgs: map[string]bool{},
+		},
+		{
+			name:         "Should not add an empty path",
+			pkgPath:      "",
+			initialPkgs:  map[string]bool{},
+			expectedPkgs: map[string]bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addPackage(tt.pkgPath, tt.initialPkgs)
+			assert.Equal(t, tt.expectedPkgs, tt.initialPkgs)
+		})
+	}
+
+	assert.NotPanics(t, func() { addPackage("github.com/foo/dto", nil) }, "a nil map is ignored")
+}
+
+func TestPackageNameFromPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "time", expected: "time"},
+		{path: "github.com/foo/dto", expected: "dto"},
+		{path: "github.com/foo/bar/v2", expected: "bar"},
+		{path: "gopkg.in/yaml.v3", expected: "yaml"},
+		{path: "github.com/foo/go-dto", expected: "go_dto"},
+	}
+
+	for _, tt := range tests {
+		t.Run("Should return "+tt.expected+" for "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, packageNameFromPath(tt.path))
+		})
+	}
+}
+
+func TestParseTypeExpr(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "Should parse qualified names with dotted paths",
+			input:    "github.com/foo/dto.User",
+			expected: "dto.User",
+		},
+		{
+			name:     "Should parse arrays, pointers and maps",
+			input:    "map[string]*[4]github.com/foo/dto.User",
+			expected: "map[string][]dto.User",
+		},
+		{
+			name:     "Should skip anonymous structs with tags",
+			input:    "github.com/foo/dto.Page[struct { A string \"json:\\\"a,omitempty\\\"\" },int]",
+			expected: "dto.Page[interface{},int]",
+		},
+		{
+			name:    "Should fail on unbalanced brackets",
+			input:   "github.com/foo/dto.Page[int",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := parseTypeExpr(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, expr.render(nil))
+		})
+	}
+}
+
+func TestSchemaName(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     any
+		expected string
+	}{
+		{
+			name:     "Should use the package and type name",
+			body:     testutil.TestGeneric{},
+			expected: "testutil.TestGeneric",
+		},
+		{
+			name:     "Should suffix each type argument like swag",
+			body:     testutil.Page[testutil.TestGeneric, testutil.Cursor]{},
+			expected: "testutil.Page-testutil_TestGeneric-testutil_Cursor",
+		},
+		{
+			name:     "Should name slice and nested arguments",
+			body:     testutil.Envelope[testutil.StructGeneric[[]testutil.TestGeneric]]{},
+			expected: "testutil.Envelope-testutil_StructGeneric-array_testutil_TestGeneric",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, schemaName(reflect.TypeOf(tt.body)))
+		})
+	}
+}
+
+func TestTypeName_samePackageName(t *testing.T) {
+	pkgs := map[string]bool{
+		testutilPkg + "/billing/dto":  true,
+		testutilPkg + "/identity/dto": true,
+	}
+
+	got := typeName(reflect.TypeOf(billingdto.Page[identitydto.User]{}), pkgs)
+	assert.Equal(t, "billing_dto.Page[identity_dto.User]", got)
+
+	delete(pkgs, testutilPkg+"/billing/dto")
+	assert.Equal(t, "dto.User", typeName(reflect.TypeOf(identitydto.User{}), pkgs))
+}
+
+func TestImportAlias(t *testing.T) {
+	tests := []struct {
+		name     string
+		pkgPath  string
+		imported []string
+		expected string
+	}{
+		{
+			name:     "Should not alias a package whose name is unique",
+			pkgPath:  "github.com/foo/billing/dto",
+			imported: []string{"github.com/foo/billing/dto", "github.com/foo/models"},
+			expected: "",
+		},
+		{
+			name:     "Should not alias a package that is not imported",
+			pkgPath:  "github.com/foo/billing/dto",
+			imported: []string{"github.com/foo/identity/dto"},
+			expected: "",
+		},
+		{
+			name:     "Should prefix the name with the parent telling the packages apart",
+			pkgPath:  "github.com/foo/billing/dto",
+			imported: []string{"github.com/foo/billing/dto", "github.com/foo/identity/dto"},
+			expected: "billing_dto",
+		},
+		{
+			name:     "Should use as many parents as needed",
+			pkgPath:  "github.com/a/billing/dto",
+			imported: []string{"github.com/a/billing/dto", "github.com/b/billing/dto"},
+			expected: "a_billing_dto",
+		},
+		{
+			name:     "Should leave the major version out",
+			pkgPath:  "github.com/foo/billing/dto/v2",
+			imported: []string{"github.com/foo/billing/dto/v2", "github.com/foo/identity/dto"},
+			expected: "billing_dto",
+		},
+		{
+			name:     "Should make identifiers of the parents",
+			pkgPath:  "github.com/foo/go-billing/dto",
+			imported: []string{"github.com/foo/go-billing/dto", "github.com/foo/identity/dto"},
+			expected: "go_billing_dto",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkgs := make(map[string]bool)
+			for _, p := range tt.imported {
+				pkgs[p] = true
+			}
+			assert.Equal(t, tt.expected, importAlias(tt.pkgPath, pkgs))
+		})
+	}
+}
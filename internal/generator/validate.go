@@ -0,0 +1,379 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/diegoclair/goswag/models"
+)
+
+type Severity string
+
+const (
+	// SeverityError marks declarations that produce an invalid or wrong spec.
+	SeverityError Severity = "error"
+	// SeverityWarning marks declarations that are suspicious but still valid.
+	SeverityWarning Severity = "warning"
+)
+
+// Finding is a problem detected in the route declarations at generation time.
+type Finding struct {
+	Severity Severity
+	Method   string
+	Path     string
+	Message  string
+	// Blocking findings make the generation fail even when it is not strict:
+	// the docs they would produce are rejected by swag or break a guarantee
+	// goswag makes, like unique operation ids.
+	Blocking bool
+}
+
+func (f Finding) String() string {
+	if f.Method == "" && f.Path == "" {
+		// the findings of the declarations shared by the routes, like the
+		// security schemes, have no route
+		return fmt.Sprintf("%s: %s", f.Severity, f.Message)
+	}
+
+	return fmt.Sprintf("%s: %s %s: %s", f.Severity, f.Method, f.Path, f.Message)
+}
+
+// ValidationError is returned by the generation when a finding is blocking
+// or, when it is strict, when at least one finding has SeverityError. It
+// carries every finding, warnings included; use errors.As to get them back.
+type ValidationError struct {
+	Findings []Finding
+}
+
+func (e *ValidationError) Error() string {
+	errorCount := 0
+	for _, f := range e.Findings {
+		if f.Severity == SeverityError {
+			errorCount++
+		}
+	}
+
+	lines := make([]string, 0, len(e.Findings)+1)
+	lines = append(lines, fmt.Sprintf("goswag: %d invalid route declaration(s):", errorCount))
+
+	for _, f := range e.Findings {
+		lines = append(lines, "\t"+f.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// knownParamTypes are the data types accepted by QueryParam, HeaderParam and
+// PathParam, and by response headers. Both the goswag constants and the
+// swag/OpenAPI names are valid.
+var knownParamTypes = map[string]bool{
+	"string":  true,
+	"int":     true,
+	"integer": true,
+	"number":  true,
+	"bool":    true,
+	"boolean": true,
+	"array":   true,
+}
+
+// collectionFormats are the serializations swag accepts for array params.
+var collectionFormats = map[string]bool{
+	"csv":   true,
+	"ssv":   true,
+	"tsv":   true,
+	"pipes": true,
+	"multi": true,
+}
+
+// Validate checks the route declarations of doc and returns the findings in
+// registration order. It catches mistakes that swag would either reject with
+// a confusing message or silently turn into wrong docs.
+func Validate(doc Doc) []Finding {
+	v := &validator{
+		seen:         make(map[string]bool),
+		operationIDs: make(map[string]string),
+		schemes:      doc.SecuritySchemes,
+	}
+
+	v.securitySchemes()
+
+	// group and instance requirements are checked on the routes inheriting them
+	routes, groups := resolveSecurity(doc.Routes, doc.Groups, doc.Security)
+
+	v.routes(routes)
+	v.groups(groups)
+
+	return v.findings
+}
+
+type validator struct {
+	findings     []Finding
+	seen         map[string]bool   // method + normalized path of the routes already visited
+	operationIDs map[string]string // declared operation id -> method and path of its route
+	schemes      map[string]models.SecurityScheme
+}
+
+// oauth2URLs are the URLs swag requires for each oauth2 flow.
+var oauth2URLs = map[string][]string{
+	models.OAuth2Implicit:    {"authorization"},
+	models.OAuth2Password:    {"token"},
+	models.OAuth2Application: {"token"},
+	models.OAuth2AccessCode:  {"authorization", "token"},
+}
+
+// apiKeyLocations are the places an apiKey can be sent in.
+var apiKeyLocations = map[string]bool{
+	"header": true,
+	"query":  true,
+	"cookie": true,
+}
+
+// securitySchemes checks the registered schemes. The ones swag can't parse
+// are blocking: it fails on them, and the native document would document
+// something else.
+func (v *validator) securitySchemes() {
+	for _, name := range sortedKeys(v.schemes) {
+		scheme := v.schemes[name]
+		block := func(format string, args ...any) {
+			v.findings = append(v.findings, Finding{
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("security scheme %q ", name) + fmt.Sprintf(format, args...),
+				Blocking: true,
+			})
+		}
+
+		switch scheme.Type {
+		case models.SecurityBasic, models.SecurityBearer:
+		case models.SecurityAPIKey:
+			if scheme.Name == "" {
+				block("has no Name, the header, query param or cookie holding the key")
+			}
+			if !apiKeyLocations[scheme.In] {
+				block("has In %q, which must be header, query or cookie", scheme.In)
+			}
+		case models.SecurityOAuth2:
+			urls, ok := oauth2URLs[scheme.Flow]
+			if !ok {
+				block("has unknown oauth2 flow %q, which must be implicit, password, application or accessCode", scheme.Flow)
+			}
+			for _, url := range urls {
+				if url == "authorization" && scheme.AuthorizationURL == "" || url == "token" && scheme.TokenURL == "" {
+					block("has no %s URL, which the %s flow requires", url, scheme.Flow)
+				}
+			}
+		default:
+			block("has unknown type %q, which must be apiKey, basic, bearer or oauth2", scheme.Type)
+		}
+	}
+}
+
+func (v *validator) groups(groups []Group) {
+	for _, g := range groups {
+		v.routes(g.Routes)
+		v.groups(g.Groups)
+	}
+}
+
+func (v *validator) routes(routes []Route) {
+	for _, r := range routes {
+		v.route(r)
+	}
+}
+
+func (v *validator) route(r Route) {
+	report := func(severity Severity, format string, args ...any) {
+		v.findings = append(v.findings, Finding{
+			Severity: severity,
+			Method:   r.Method,
+			Path:     r.Path,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+	block := func(format string, args ...any) {
+		report(SeverityError, format, args...)
+		v.findings[len(v.findings)-1].Blocking = true
+	}
+
+	// placeholders only differ by name (/users/:id vs /users/:uid) still
+	// match the same requests
+	key := r.Method + " " + pathParamPattern.ReplaceAllString(r.Path, "{}")
+	if v.seen[key] {
+		report(SeverityError, "route is registered more than once")
+	}
+	v.seen[key] = true
+
+	if r.OperationID != "" {
+		if first, ok := v.operationIDs[r.OperationID]; ok {
+			block("operation id %q is already used by %s", r.OperationID, first)
+		} else {
+			v.operationIDs[r.OperationID] = r.Method + " " + r.Path
+		}
+	}
+
+	for _, key := range sortedKeys(r.Extensions) {
+		if _, err := json.Marshal(r.Extensions[key]); err != nil {
+			report(SeverityError, "extension %q can't be encoded as JSON: %v", extensionKey(key), err)
+		}
+	}
+
+	placeholders := make(map[string]bool)
+	for _, match := range pathParamPattern.FindAllStringSubmatch(r.Path, -1) {
+		placeholders[match[1]] = true
+	}
+
+	declared := make(map[string]bool)
+	for _, p := range r.PathParams {
+		declared[p.Name] = true
+		if !placeholders[p.Name] {
+			report(SeverityError, "path param %q is declared but the path has no :%s placeholder", p.Name, p.Name)
+		}
+	}
+
+	for _, match := range pathParamPattern.FindAllStringSubmatch(r.Path, -1) {
+		if !declared[match[1]] {
+			report(SeverityError, "path placeholder %s has no matching PathParam", match[0])
+		}
+	}
+
+	for _, params := range []struct {
+		location string
+		list     []Param
+	}{
+		{"path", r.PathParams},
+		{"query", r.QueryParams},
+		{"header", r.HeaderParams},
+		{"form", r.FormParams},
+	} {
+		for _, p := range params.list {
+			if p.ParamType == FileParamType && params.location == "form" {
+				continue
+			}
+			if !knownParamTypes[p.ParamType] {
+				report(SeverityError, "%s param %q has unknown data type %q", params.location, p.Name, p.ParamType)
+			}
+			v.paramAttributes(report, block, params.location, p)
+		}
+	}
+
+	for _, ret := range r.Returns {
+		if ret.Default && ret.StatusCode != 0 {
+			report(SeverityWarning, "response %d is marked as default, its status code is ignored", ret.StatusCode)
+		}
+
+		for _, h := range ret.Headers {
+			if h.Type != "" && !knownParamTypes[h.Type] {
+				report(SeverityError, "header %q of response %s has unknown data type %q", h.Name, responseCode(ret), h.Type)
+			}
+		}
+
+		if len(ret.OverrideStructFields) > 0 && ret.Body == nil {
+			report(SeverityError, "response %s overrides fields but has no body", responseCode(ret))
+		} else {
+			for _, err := range checkOverrides(ret.Body, ret.OverrideStructFields) {
+				report(SeverityError, "response %s: %v", responseCode(ret), err)
+			}
+		}
+
+		if len(ret.Examples) > 0 && ret.Body == nil {
+			block("response %s has examples but no body", responseCode(ret))
+			continue
+		}
+
+		for _, name := range sortedKeys(ret.Examples) {
+			if err := matchExample(ret.Examples[name], ret.Body); err != nil {
+				block("example %q of response %s %v", name, responseCode(ret), err)
+			}
+		}
+	}
+
+	for _, err := range checkOverrides(r.Reads, r.ReadOverrides) {
+		report(SeverityError, "request body: %v", err)
+	}
+
+	if len(r.ReadExamples) > 0 && r.Reads == nil {
+		block("route has request examples but no Read body")
+	} else {
+		for i, example := range r.ReadExamples {
+			if err := matchExample(example, r.Reads); err != nil {
+				block("request example %d %v", i+1, err)
+			}
+		}
+	}
+
+	for _, req := range r.Security {
+		scheme, ok := v.schemes[req.Scheme]
+		if !ok {
+			report(SeverityError, "security scheme %q is not registered, add it with AddSecurityScheme", req.Scheme)
+			continue
+		}
+
+		for _, scope := range req.Scopes {
+			if _, ok := scheme.Scopes[scope]; !ok && scheme.Type == models.SecurityOAuth2 {
+				report(SeverityWarning, "scope %q is not declared by the security scheme %q", scope, req.Scheme)
+			}
+		}
+	}
+
+	if r.Reads != nil && len(r.FormParams) > 0 {
+		report(SeverityError, "route declares both a Read body and form params, only one request body is allowed")
+	}
+
+	if r.Reads != nil && (r.Method == http.MethodGet || r.Method == http.MethodHead) {
+		report(SeverityWarning, "%s requests should not have a body, the Read declaration is ignored by most clients", r.Method)
+	}
+}
+
+func (v *validator) paramAttributes(report func(Severity, string, ...any), block func(string, ...any), location string, p Param) {
+	// swag splits Enums(...) on commas and trims the values, without quoting
+	for _, e := range p.Enum {
+		if value := fmt.Sprint(e); strings.Contains(value, ",") || strings.TrimSpace(value) != value {
+			block("%s param %q has enum value %q, which can't contain commas or start or end with spaces", location, p.Name, value)
+		}
+	}
+
+	if p.ParamType == ArrayParamType {
+		if p.Items != "" && (p.Items == ArrayParamType || !knownParamTypes[p.Items]) {
+			report(SeverityError, "%s param %q has unknown items data type %q", location, p.Name, p.Items)
+		}
+		if p.CollectionFormat != "" && !collectionFormats[p.CollectionFormat] {
+			report(SeverityError, "%s param %q has unknown collection format %q", location, p.Name, p.CollectionFormat)
+		}
+		if p.CollectionFormat == "multi" && location != "query" && location != "form" {
+			report(SeverityError, "%s param %q can't use the multi collection format, only query and form params can", location, p.Name)
+		}
+	} else if p.Items != "" || p.CollectionFormat != "" {
+		report(SeverityWarning, "%s param %q has items but its data type is %q, not array", location, p.Name, p.ParamType)
+	}
+
+	if p.Minimum != nil && p.Maximum != nil && *p.Minimum > *p.Maximum {
+		report(SeverityError, "%s param %q has a minimum greater than its maximum", location, p.Name)
+	}
+
+	if p.MinLength != nil && p.MaxLength != nil && *p.MinLength > *p.MaxLength {
+		report(SeverityError, "%s param %q has a minLength greater than its maxLength", location, p.Name)
+	}
+}
+
+// hasBlocking reports whether any finding is blocking.
+func hasBlocking(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Blocking {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasErrors reports whether any finding has SeverityError.
+func hasErrors(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,351 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/diegoclair/goswag/internal/generator/testutil"
+	"github.com/diegoclair/goswag/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  Doc
+		want []Finding
+	}{
+		{
+			name: "Should return no findings for a valid declaration",
+			doc: Doc{Routes: []Route{
+				{
+					Method:      "GET",
+					Path:        "/users/:id",
+					PathParams:  []Param{{Name: "id", ParamType: "int", Required: true}},
+					QueryParams: []Param{{Name: "expand", ParamType: "boolean"}},
+				},
+				{Method: "POST", Path: "/users", Reads: models.ReturnType{}},
+			}},
+			want: nil,
+		},
+		{
+			name: "Should report placeholders without path params and path params without placeholders",
+			doc: Doc{Routes: []Route{
+				{
+					Method:     "GET",
+					Path:       "/users/:id",
+					PathParams: []Param{{Name: "user_id", ParamType: "string"}},
+				},
+			}},
+			want: []Finding{
+				{Severity: SeverityError, Method: "GET", Path: "/users/:id", Message: `path param "user_id" is declared but the path has no :user_id placeholder`},
+				{Severity: SeverityError, Method: "GET", Path: "/users/:id", Message: "path placeholder :id has no matching PathParam"},
+			},
+		},
+		{
+			name: "Should report the same method and path registered twice, across groups",
+			doc: Doc{
+				Routes: []Route{{Method: "DELETE", Path: "/users/:id", PathParams: []Param{{Name: "id", ParamType: "string"}}}},
+				Groups: []Group{{Groups: []Group{{Routes: []Route{
+					{Method: "DELETE", Path: "/users/:uid", PathParams: []Param{{Name: "uid", ParamType: "string"}}},
+					{Method: "GET", Path: "/users/:uid", PathParams: []Param{{Name: "uid", ParamType: "string"}}},
+				}}}}},
+			},
+			want: []Finding{
+				{Severity: SeverityError, Method: "DELETE", Path: "/users/:uid", Message: "route is registered more than once"},
+			},
+		},
+		{
+			name: "Should report unknown param data types",
+			doc: Doc{Routes: []Route{
+				{
+					Method:       "GET",
+					Path:         "/search",
+					QueryParams:  []Param{{Name: "page", ParamType: "integr"}},
+					HeaderParams: []Param{{Name: "X-Trace", ParamType: "uuid"}},
+				},
+			}},
+			want: []Finding{
+				{Severity: SeverityError, Method: "GET", Path: "/search", Message: `query param "page" has unknown data type "integr"`},
+				{Severity: SeverityError, Method: "GET", Path: "/search", Message: `header param "X-Trace" has unknown data type "uuid"`},
+			},
+		},
+		{
+			name: "Should report unknown response header data types",
+			doc: Doc{Routes: []Route{
+				{
+					Method: "POST",
+					Path:   "/users",
+					Returns: []models.ReturnType{{
+						StatusCode: 201,
+						Headers:    []models.ResponseHeader{{Name: "Location"}, {Name: "X-Count", Type: "long"}},
+					}},
+				},
+			}},
+			want: []Finding{
+				{Severity: SeverityError, Method: "POST", Path: "/users", Message: `header "X-Count" of response 201 has unknown data type "long"`},
+			},
+		},
+		{
+			name: "Should warn about default responses with a status code",
+			doc: Doc{Routes: []Route{
+				{Method: "GET", Path: "/a", Returns: []models.ReturnType{{StatusCode: 500, Default: true}}},
+			}},
+			want: []Finding{
+				{Severity: SeverityWarning, Method: "GET", Path: "/a", Message: "response 500 is marked as default, its status code is ignored"},
+			},
+		},
+		{
+			name: "Should report form params mixed with a body and unknown form data types",
+			doc: Doc{Routes: []Route{
+				{
+					Method: "POST",
+					Path:   "/upload",
+					Reads:  models.ReturnType{},
+					FormParams: []Param{
+						{Name: "file", ParamType: FileParamType},
+						{Name: "size", ParamType: "long"},
+					},
+				},
+			}},
+			want: []Finding{
+				{Severity: SeverityError, Method: "POST", Path: "/upload", Message: `form param "size" has unknown data type "long"`},
+				{Severity: SeverityError, Method: "POST", Path: "/upload", Message: "route declares both a Read body and form params, only one request body is allowed"},
+			},
+		},
+		{
+			name: "Should report invalid param attributes",
+			doc: Doc{Routes: []Route{
+				{
+					Method: "GET",
+					Path:   "/search",
+					QueryParams: []Param{
+						{Name: "ids", ParamType: "array", ParamAttributes: models.ParamAttributes{Items: "uuid", CollectionFormat: "comma"}},
+						{Name: "page", ParamType: "int", ParamAttributes: models.ParamAttributes{Items: "int", Minimum: ptr(10.0), Maximum: ptr(1.0)}},
+					},
+					HeaderParams: []Param{
+						{Name: "X-Ids", ParamType: "array", ParamAttributes: models.ParamAttributes{CollectionFormat: "multi", MinLength: ptr(5), MaxLength: ptr(1)}},
+					},
+				},
+			}},
+			want: []Finding{
+				{Severity: SeverityError, Method: "GET", Path: "/search", Message: `query param "ids" has unknown items data type "uuid"`},
+				{Severity: SeverityError, Method: "GET", Path: "/search", Message: `query param "ids" has unknown collection format "comma"`},
+				{Severity: SeverityWarning, Method: "GET", Path: "/search", Message: `query param "page" has items but its data type is "int", not array`},
+				{Severity: SeverityError, Method: "GET", Path: "/search", Message: `query param "page" has a minimum greater than its maximum`},
+				{Severity: SeverityError, Method: "GET", Path: "/search", Message: `header param "X-Ids" can't use the multi collection format, only query and form params can`},
+				{Severity: SeverityError, Method: "GET", Path: "/search", Message: `header param "X-Ids" has a minLength greater than its maxLength`},
+			},
+		},
+		{
+			name: "Should block enum values swag would split",
+			doc: Doc{Routes: []Route{
+				{
+					Method: "GET",
+					Path:   "/search",
+					QueryParams: []Param{
+						{Name: "sort", ParamType: "string", ParamAttributes: models.ParamAttributes{Enum: []any{"name asc", "name,desc", " date"}}},
+					},
+				},
+			}},
+			want: []Finding{
+				{Severity: SeverityError, Method: "GET", Path: "/search", Message: `query param "sort" has enum value "name,desc", which can't contain commas or start or end with spaces`, Blocking: true},
+				{Severity: SeverityError, Method: "GET", Path: "/search", Message: `query param "sort" has enum value " date", which can't contain commas or start or end with spaces`, Blocking: true},
+			},
+		},
+		{
+			name: "Should report unregistered security schemes and undeclared oauth2 scopes, inherited ones included",
+			doc: Doc{
+				Routes: []Route{
+					{Method: "GET", Path: "/me"},
+					{Method: "GET", Path: "/health", Public: true},
+					{Method: "GET", Path: "/reports", Security: []SecurityRequirement{{Scheme: "OAuth2", Scopes: []string{"read", "admin"}}}},
+				},
+				Groups:          []Group{{Security: []SecurityRequirement{{Scheme: "Basic"}}, Routes: []Route{{Method: "GET", Path: "/admin"}}}},
+				Security:        []SecurityRequirement{{Scheme: "Bearer"}},
+				SecuritySchemes: map[string]models.SecurityScheme{"OAuth2": {Type: models.SecurityOAuth2, Flow: models.OAuth2Application, TokenURL: "https://auth.example.com/token", Scopes: map[string]string{"read": "Read"}}},
+			},
+			want: []Finding{
+				{Severity: SeverityError, Method: "GET", Path: "/me", Message: `security scheme "Bearer" is not registered, add it with AddSecurityScheme`},
+				{Severity: SeverityWarning, Method: "GET", Path: "/reports", Message: `scope "admin" is not declared by the security scheme "OAuth2"`},
+				{Severity: SeverityError, Method: "GET", Path: "/admin", Message: `security scheme "Basic" is not registered, add it with AddSecurityScheme`},
+			},
+		},
+		{
+			name: "Should report duplicated operation ids and extensions that are not JSON",
+			doc: Doc{
+				Routes: []Route{{Method: "GET", Path: "/users", OperationID: "listUsers"}},
+				Groups: []Group{{Routes: []Route{
+					{Method: "GET", Path: "/v2/users", OperationID: "listUsers", Extensions: map[string]any{"bad": func() {}}},
+				}}},
+			},
+			want: []Finding{
+				{Severity: SeverityError, Method: "GET", Path: "/v2/users", Message: `operation id "listUsers" is already used by GET /users`, Blocking: true},
+				{Severity: SeverityError, Method: "GET", Path: "/v2/users", Message: `extension "x-bad" can't be encoded as JSON: json: unsupported type: func()`},
+			},
+		},
+		{
+			name: "Should report examples that don't match their body",
+			doc: Doc{Routes: []Route{
+				{
+					Method:       "POST",
+					Path:         "/users",
+					Reads:        testutil.TestGeneric{},
+					ReadExamples: []any{testutil.TestGeneric{Name: "John"}, map[string]any{"Nick": "John"}},
+					Returns: []models.ReturnType{
+						{StatusCode: 201, Body: testutil.TestGeneric{}, Examples: map[string]any{"created": map[string]any{"Name": 1}}},
+						{StatusCode: 204, Examples: map[string]any{"empty": ""}},
+					},
+				},
+				{Method: "DELETE", Path: "/users", ReadExamples: []any{testutil.TestGeneric{}}},
+			}},
+			want: []Finding{
+				{Severity: SeverityError, Method: "POST", Path: "/users", Message: `example "created" of response 201 does not match testutil.TestGeneric: json: cannot unmarshal number into Go struct field TestGeneric.Name of type string`, Blocking: true},
+				{Severity: SeverityError, Method: "POST", Path: "/users", Message: "response 204 has examples but no body", Blocking: true},
+				{Severity: SeverityError, Method: "POST", Path: "/users", Message: `request example 2 does not match testutil.TestGeneric: json: unknown field "Nick"`, Blocking: true},
+				{Severity: SeverityError, Method: "DELETE", Path: "/users", Message: "route has request examples but no Read body", Blocking: true},
+			},
+		},
+		{
+			name: "Should report overrides of fields the bodies don't have",
+			doc: Doc{Routes: []Route{{
+				Method:        "POST",
+				Path:          "/commands",
+				Reads:         testutil.OverrideStruct{},
+				ReadOverrides: map[string]any{"payload": testutil.TestGeneric{}},
+				Returns: []models.ReturnType{
+					{StatusCode: 200, Body: testutil.Envelope[any]{}, OverrideStructFields: map[string]any{"Data.Items": []testutil.TestGeneric{}}},
+					{StatusCode: 204, OverrideStructFields: map[string]any{"Data": testutil.TestGeneric{}}},
+				},
+			}}},
+			want: []Finding{
+				{Severity: SeverityError, Method: "POST", Path: "/commands", Message: `response 200: override "Data.Items" does not match a JSON field of interface{}`},
+				{Severity: SeverityError, Method: "POST", Path: "/commands", Message: "response 204 overrides fields but has no body"},
+				{Severity: SeverityError, Method: "POST", Path: "/commands", Message: `request body: override "payload" does not match a JSON field of testutil.OverrideStruct`},
+			},
+		},
+		{
+			name: "Should block the security schemes swag can't parse",
+			doc: Doc{SecuritySchemes: map[string]models.SecurityScheme{
+				"ApiKey":   {Type: models.SecurityAPIKey},
+				"Cookie":   {Type: models.SecurityAPIKey, In: "cookie", Name: "session"},
+				"Implicit": {Type: models.SecurityOAuth2, Flow: models.OAuth2Implicit},
+				"OAuth2":   {Type: models.SecurityOAuth2, Flow: "clientCredentials", TokenURL: "https://auth.example.com/token"},
+				"Token":    {Type: "jwt"},
+			}},
+			want: []Finding{
+				{Severity: SeverityError, Message: `security scheme "ApiKey" has no Name, the header, query param or cookie holding the key`, Blocking: true},
+				{Severity: SeverityError, Message: `security scheme "ApiKey" has In "", which must be header, query or cookie`, Blocking: true},
+				{Severity: SeverityError, Message: `security scheme "Implicit" has no authorization URL, which the implicit flow requires`, Blocking: true},
+				{Severity: SeverityError, Message: `security scheme "OAuth2" has unknown oauth2 flow "clientCredentials", which must be implicit, password, application or accessCode`, Blocking: true},
+				{Severity: SeverityError, Message: `security scheme "Token" has unknown type "jwt", which must be apiKey, basic, bearer or oauth2`, Blocking: true},
+			},
+		},
+		{
+			name: "Should warn about request bodies on GET and HEAD",
+			doc: Doc{Routes: []Route{
+				{Method: "GET", Path: "/a", Reads: models.ReturnType{}},
+				{Method: "HEAD", Path: "/a", Reads: models.ReturnType{}},
+			}},
+			want: []Finding{
+				{Severity: SeverityWarning, Method: "GET", Path: "/a", Message: "GET requests should not have a body, the Read declaration is ignored by most clients"},
+				{Severity: SeverityWarning, Method: "HEAD", Path: "/a", Message: "HEAD requests should not have a body, the Read declaration is ignored by most clients"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Validate(tt.doc))
+		})
+	}
+}
+
+func TestGenerate_strict(t *testing.T) {
+	doc := Doc{Routes: []Route{
+		{Method: "GET", Path: "/users/:id", Reads: models.ReturnType{}},
+	}}
+
+	t.Run("Should only log findings when not strict", func(t *testing.T) {
+		dir := t.TempDir()
+		logger := &testLogger{}
+
+		require.NoError(t, Generate(doc, WithOutputDir(dir), WithLogger(logger)))
+		assert.Contains(t, logger.lines, "error: GET /users/:id: path placeholder :id has no matching PathParam")
+		assert.Contains(t, logger.lines, "warning: GET /users/:id: GET requests should not have a body, the Read declaration is ignored by most clients")
+		assert.FileExists(t, filepath.Join(dir, "goswag.go"))
+	})
+
+	t.Run("Should fail without writing the stub when strict", func(t *testing.T) {
+		dir := t.TempDir()
+
+		err := Generate(doc, WithOutputDir(dir), WithLogger(&testLogger{}), WithStrict())
+
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Len(t, validationErr.Findings, 2)
+		assert.Equal(t, "goswag: 1 invalid route declaration(s):\n"+
+			"\terror: GET /users/:id: path placeholder :id has no matching PathParam\n"+
+			"\twarning: GET /users/:id: GET requests should not have a body, the Read declaration is ignored by most clients",
+			err.Error())
+
+		_, statErr := os.Stat(filepath.Join(dir, "goswag.go"))
+		assert.True(t, os.IsNotExist(statErr))
+	})
+
+	t.Run("Should fail on blocking findings when not strict", func(t *testing.T) {
+		dir := t.TempDir()
+
+		err := Generate(Doc{Routes: []Route{
+			{Method: "GET", Path: "/users", OperationID: "listUsers"},
+			{Method: "GET", Path: "/v2/users", OperationID: "listUsers"},
+		}}, WithOutputDir(dir), WithLogger(&testLogger{}))
+
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.True(t, validationErr.Findings[0].Blocking)
+
+		_, statErr := os.Stat(filepath.Join(dir, "goswag.go"))
+		assert.True(t, os.IsNotExist(statErr))
+	})
+
+	t.Run("Should fail on an operation id reused by the route of another spec", func(t *testing.T) {
+		dir := t.TempDir()
+
+		err := Generate(Doc{
+			Groups: []Group{
+				{GroupName: "/v1", Spec: "v1", Routes: []Route{{Method: "GET", Path: "/v1/users", OperationID: "listUsers"}}},
+				{GroupName: "/v2", Spec: "v2", Routes: []Route{{Method: "GET", Path: "/v2/users", OperationID: "listUsers"}}},
+			},
+		}, WithOutputDir(dir), WithLogger(&testLogger{}))
+
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, []Finding{{
+			Severity: SeverityError,
+			Method:   "GET",
+			Path:     "/v2/users",
+			Message:  `operation id "listUsers" is already used by GET /v1/users`,
+			Blocking: true,
+		}}, validationErr.Findings)
+
+		assert.NoDirExists(t, filepath.Join(dir, "v1"))
+	})
+
+	t.Run("Should fail on security schemes swag can't parse when not strict", func(t *testing.T) {
+		err := Generate(Doc{SecuritySchemes: map[string]models.SecurityScheme{"OAuth2": {Type: models.SecurityOAuth2}}},
+			WithOutputDir(t.TempDir()), WithLogger(&testLogger{}))
+
+		assert.EqualError(t, err, "goswag: 1 invalid route declaration(s):\n"+
+			`	error: security scheme "OAuth2" has unknown oauth2 flow "", which must be implicit, password, application or accessCode`)
+	})
+
+	t.Run("Should not fail on warnings only when strict", func(t *testing.T) {
+		err := Generate(Doc{Routes: []Route{{Method: "GET", Path: "/a", Reads: models.ReturnType{}}}},
+			WithOutputDir(t.TempDir()), WithLogger(&testLogger{}), WithStrict())
+		assert.NoError(t, err)
+	})
+}
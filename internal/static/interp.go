@@ -0,0 +1,1108 @@
+package static
+
+import (
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/diegoclair/goswag/internal/frameworks/shared"
+	"github.com/diegoclair/goswag/internal/generator"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// maxDepth bounds the calls followed from main, recursive helpers included.
+const maxDepth = 32
+
+// skippedWithStatic are the route methods that need the value of their
+// argument, not only its type: they are skipped when it is a user type.
+var skippedWithStatic = map[string]bool{
+	"Example": true,
+}
+
+// paramsMethods are the route methods reading the params of their argument
+// from its type: a user type is replaced by its params type.
+var paramsMethods = map[string]bool{
+	"Params":   true,
+	"ReadForm": true,
+}
+
+// outputOptions are the GenerateSwaggerWith options of the source that are
+// applied to the stub extracted, by qualified name: the ones setting where
+// and how it is written.
+var outputOptions = map[string]func(string) generator.Option{
+	goswagPath + ".WithOutputDir":   generator.WithOutputDir,
+	goswagPath + ".WithFileName":    generator.WithFileName,
+	goswagPath + ".WithPackageName": generator.WithPackageName,
+}
+
+// interp follows the statements of the source, evaluating what it can and
+// replaying the goswag calls. Branches are all taken and the calls of
+// functions declared in the module are followed, so every route the source
+// may register is registered. Loops run for every element of a slice known
+// from the source, and once otherwise: the routes of their other iterations
+// are missing, which is reported.
+type interp struct {
+	fset *token.FileSet
+
+	funcs  map[*types.Func]funcSource
+	vars   map[*types.Var]varSource
+	global map[*types.Var]reflect.Value
+
+	// fields holds what is stored in struct fields, whatever the struct:
+	// values of user types are not built, but groups are often kept in them.
+	fields map[*types.Var]reflect.Value
+
+	dir       string // the folder of the main package, running the source in it
+	instances []Generator
+	generated Generator
+	options   []generator.Option // the output options of the generated instance
+	warnings  []Warning
+	depth     int
+	handlers  int // handlers that got a generated name
+
+	// loops are the loops being run once, their iterations being unknown:
+	// the routes registered in them are reported, once per loop.
+	loops    []ast.Stmt
+	reported map[ast.Stmt]bool
+}
+
+type funcSource struct {
+	pkg  *packages.Package
+	decl *ast.FuncDecl
+}
+
+type varSource struct {
+	pkg   *packages.Package
+	value ast.Expr
+}
+
+// frame is the state of a function being followed.
+type frame struct {
+	pkg    *packages.Package
+	decl   *ast.FuncDecl // the declaration the function is in, for closures
+	parent *frame        // the frame a closure is declared in
+
+	vars     map[types.Object]reflect.Value
+	funcRefs map[types.Object]funcRef
+	typeArgs map[*types.TypeParam]types.Type
+
+	results  []reflect.Value
+	returned bool
+}
+
+// funcRef is the expression a variable holding a function was set to, in
+// the frame it was evaluated in: handlers are named after it.
+type funcRef struct {
+	expr  ast.Expr
+	frame *frame
+}
+
+func newInterp(fset *token.FileSet, pkgs []*packages.Package) *interp {
+	in := &interp{
+		fset:   fset,
+		funcs:  make(map[*types.Func]funcSource),
+		vars:   make(map[*types.Var]varSource),
+		global: make(map[*types.Var]reflect.Value),
+		fields: make(map[*types.Var]reflect.Value),
+
+		reported: make(map[ast.Stmt]bool),
+	}
+
+	for _, p := range pkgs {
+		for _, file := range p.Syntax {
+			for _, decl := range file.Decls {
+				in.declare(p, decl)
+			}
+		}
+	}
+
+	return in
+}
+
+func (in *interp) declare(p *packages.Package, decl ast.Decl) {
+	switch decl := decl.(type) {
+	case *ast.FuncDecl:
+		if fn, ok := p.TypesInfo.Defs[decl.Name].(*types.Func); ok {
+			in.funcs[fn] = funcSource{pkg: p, decl: decl}
+		}
+
+	case *ast.GenDecl:
+		for _, spec := range decl.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok || len(vs.Values) != len(vs.Names) {
+				continue
+			}
+
+			for i, name := range vs.Names {
+				if v, ok := p.TypesInfo.Defs[name].(*types.Var); ok {
+					in.vars[v] = varSource{pkg: p, value: vs.Values[i]}
+				}
+			}
+		}
+	}
+}
+
+func (in *interp) warnf(node ast.Node, format string, args ...any) {
+	in.warnings = append(in.warnings, Warning{Pos: in.fset.Position(node.Pos()), Msg: fmt.Sprintf(format, args...)})
+}
+
+func (f *frame) info() *types.Info {
+	return f.pkg.TypesInfo
+}
+
+func (f *frame) typeArg(tp *types.TypeParam) types.Type {
+	for ; f != nil; f = f.parent {
+		if arg, ok := f.typeArgs[tp]; ok {
+			return arg
+		}
+	}
+
+	return nil
+}
+
+// lookup returns the frame holding the local variable obj.
+func (f *frame) lookup(obj types.Object) (*frame, bool) {
+	for ; f != nil; f = f.parent {
+		if _, ok := f.vars[obj]; ok {
+			return f, true
+		}
+	}
+
+	return nil, false
+}
+
+func (f *frame) funcRef(obj types.Object) (funcRef, bool) {
+	for ; f != nil; f = f.parent {
+		if ref, ok := f.funcRefs[obj]; ok {
+			return ref, true
+		}
+	}
+
+	return funcRef{}, false
+}
+
+func (f *frame) bind(obj types.Object, v reflect.Value, expr ast.Expr, from *frame) {
+	if f.vars == nil {
+		f.vars = make(map[types.Object]reflect.Value)
+	}
+	f.vars[obj] = v
+
+	if _, ok := obj.Type().Underlying().(*types.Signature); ok && expr != nil {
+		if f.funcRefs == nil {
+			f.funcRefs = make(map[types.Object]funcRef)
+		}
+		f.funcRefs[obj] = funcRef{expr: expr, frame: from}
+	}
+}
+
+// run follows the statements of a function body.
+func (in *interp) run(f *frame, body *ast.BlockStmt) {
+	if body == nil {
+		return
+	}
+
+	for _, stmt := range body.List {
+		if f.returned {
+			return
+		}
+		in.exec(f, stmt)
+	}
+}
+
+// branch follows a block that may not run: a return in it doesn't end the
+// function.
+func (in *interp) branch(f *frame, stmts ...ast.Stmt) {
+	for _, stmt := range stmts {
+		if f.returned {
+			break
+		}
+		in.exec(f, stmt)
+	}
+
+	f.returned = false
+}
+
+func (in *interp) exec(f *frame, stmt ast.Stmt) {
+	switch s := stmt.(type) {
+	case *ast.ExprStmt:
+		in.evalMulti(f, s.X)
+
+	case *ast.AssignStmt:
+		in.assign(f, s.Lhs, s.Rhs)
+
+	case *ast.DeclStmt:
+		gen, ok := s.Decl.(*ast.GenDecl)
+		if !ok {
+			return
+		}
+		for _, spec := range gen.Specs {
+			if vs, ok := spec.(*ast.ValueSpec); ok && len(vs.Values) > 0 {
+				lhs := make([]ast.Expr, len(vs.Names))
+				for i, name := range vs.Names {
+					lhs[i] = name
+				}
+				in.assign(f, lhs, vs.Values)
+			}
+		}
+
+	case *ast.BlockStmt:
+		in.run(f, s)
+
+	case *ast.LabeledStmt:
+		in.exec(f, s.Stmt)
+
+	case *ast.IfStmt:
+		if s.Init != nil {
+			in.exec(f, s.Init)
+		}
+		in.branch(f, s.Body.List...)
+		if s.Else != nil {
+			in.branch(f, s.Else)
+		}
+
+	case *ast.SwitchStmt:
+		if s.Init != nil {
+			in.exec(f, s.Init)
+		}
+		for _, clause := range s.Body.List {
+			in.branch(f, clause.(*ast.CaseClause).Body...)
+		}
+
+	case *ast.TypeSwitchStmt:
+		for _, clause := range s.Body.List {
+			in.branch(f, clause.(*ast.CaseClause).Body...)
+		}
+
+	case *ast.ForStmt:
+		if s.Init != nil {
+			in.exec(f, s.Init)
+		}
+		in.runOnce(f, s, s.Body)
+
+	case *ast.RangeStmt:
+		in.rangeLoop(f, s)
+
+	case *ast.ReturnStmt:
+		var results []reflect.Value
+		if len(s.Results) == 1 {
+			results = in.evalMulti(f, s.Results[0])
+		} else {
+			for _, r := range s.Results {
+				results = append(results, in.eval(f, r, nil))
+			}
+		}
+		f.results = results
+		f.returned = true
+
+	case *ast.DeferStmt:
+		in.evalMulti(f, s.Call)
+
+	case *ast.GoStmt:
+		in.evalMulti(f, s.Call)
+	}
+}
+
+// rangeLoop runs the body of the loop for every element of the ranged
+// value when it is known, or else once with unknown elements.
+func (in *interp) rangeLoop(f *frame, s *ast.RangeStmt) {
+	x := indirect(in.eval(f, s.X, nil))
+
+	if !x.IsValid() || (x.Kind() != reflect.Slice && x.Kind() != reflect.Array) {
+		in.runOnce(f, s, s.Body)
+		return
+	}
+
+	for i := 0; i < x.Len(); i++ {
+		if s.Key != nil {
+			in.store(f, s.Key, reflect.ValueOf(i), nil)
+		}
+		if s.Value != nil {
+			in.store(f, s.Value, x.Index(i), nil)
+		}
+		in.branch(f, s.Body.List...)
+	}
+}
+
+// runOnce runs the body of a loop whose iterations are unknown once.
+func (in *interp) runOnce(f *frame, loop ast.Stmt, body *ast.BlockStmt) {
+	in.loops = append(in.loops, loop)
+	defer func() { in.loops = in.loops[:len(in.loops)-1] }()
+
+	in.branch(f, body.List...)
+}
+
+// reportLoops warns about the loops run once a route is registered in: the
+// routes of their other iterations are missing.
+func (in *interp) reportLoops() {
+	for _, loop := range in.loops {
+		if !in.reported[loop] {
+			in.reported[loop] = true
+			in.warnf(loop, "routes are registered in this loop, whose iterations can't be evaluated: it is run once, so the ones of its other iterations are missing")
+		}
+	}
+}
+
+func (in *interp) assign(f *frame, lhs, rhs []ast.Expr) {
+	if len(rhs) == 1 && len(lhs) > 1 {
+		values := in.evalMulti(f, rhs[0])
+		for i, l := range lhs {
+			var v reflect.Value
+			if i < len(values) {
+				v = values[i]
+			}
+			in.store(f, l, v, nil)
+		}
+		return
+	}
+
+	for i, l := range lhs {
+		if i < len(rhs) {
+			in.store(f, l, in.eval(f, rhs[i], nil), rhs[i])
+		}
+	}
+}
+
+// store sets the variable or the struct field of lhs. expr is the value
+// expression, kept for the variables holding handlers.
+func (in *interp) store(f *frame, lhs ast.Expr, v reflect.Value, expr ast.Expr) {
+	switch l := ast.Unparen(lhs).(type) {
+	case *ast.Ident:
+		obj := f.info().ObjectOf(l)
+		if obj == nil || l.Name == "_" {
+			return
+		}
+
+		if global, ok := obj.(*types.Var); ok && isPackageVar(global) {
+			in.global[global] = v
+			return
+		}
+
+		target := f
+		if holder, ok := f.lookup(obj); ok {
+			target = holder
+		}
+		target.bind(obj, v, expr, f)
+
+	case *ast.SelectorExpr:
+		if sel, ok := f.info().Selections[l]; ok && sel.Kind() == types.FieldVal {
+			in.fields[sel.Obj().(*types.Var)] = v
+		}
+	}
+}
+
+func isPackageVar(v *types.Var) bool {
+	return v.Pkg() != nil && v.Parent() == v.Pkg().Scope()
+}
+
+// evalMulti evaluates an expression that may have several results: a call.
+func (in *interp) evalMulti(f *frame, expr ast.Expr) []reflect.Value {
+	if call, ok := ast.Unparen(expr).(*ast.CallExpr); ok {
+		return in.call(f, call)
+	}
+
+	return []reflect.Value{in.eval(f, expr, nil)}
+}
+
+// eval returns the value of expr, converted to want when it is set. It is
+// invalid when the value can't be known, or a generator.StaticType when it
+// is a value of a user type.
+func (in *interp) eval(f *frame, expr ast.Expr, want reflect.Type) reflect.Value {
+	tv := f.info().Types[expr]
+
+	if tv.Value != nil {
+		return constValue(tv.Value, tv.Type, want)
+	}
+	if tv.IsNil() && want != nil {
+		return reflect.Zero(want)
+	}
+
+	v := in.evalExpr(f, expr, want)
+	if !v.IsValid() && tv.Type != nil && (want == nil || want.Kind() == reflect.Interface) && isUserValue(tv.Type) {
+		return reflect.ValueOf(f.staticType(tv.Type))
+	}
+
+	return convert(v, want)
+}
+
+func (in *interp) evalExpr(f *frame, expr ast.Expr, want reflect.Type) reflect.Value {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return in.eval(f, e.X, want)
+
+	case *ast.Ident:
+		return in.ident(f, f.info().Uses[e])
+
+	case *ast.SelectorExpr:
+		if sel, ok := f.info().Selections[e]; ok {
+			if sel.Kind() != types.FieldVal {
+				return reflect.Value{}
+			}
+
+			x := indirect(in.eval(f, e.X, nil))
+			if x.IsValid() && x.Kind() == reflect.Struct && !isStatic(x) {
+				return x.FieldByName(e.Sel.Name)
+			}
+
+			return in.fields[sel.Obj().(*types.Var)]
+		}
+
+		return in.ident(f, f.info().Uses[e.Sel]) // qualified identifier
+
+	case *ast.CompositeLit:
+		return in.composite(f, e, want)
+
+	case *ast.UnaryExpr:
+		if e.Op != token.AND {
+			return reflect.Value{}
+		}
+
+		var elem reflect.Type
+		if want != nil && want.Kind() == reflect.Pointer {
+			elem = want.Elem()
+		}
+
+		v := in.eval(f, e.X, elem)
+		if !v.IsValid() || isStatic(v) {
+			return v
+		}
+
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		return p
+
+	case *ast.StarExpr:
+		return indirect(in.eval(f, e.X, nil))
+
+	case *ast.CallExpr:
+		if results := in.call(f, e); len(results) > 0 {
+			return results[0]
+		}
+	}
+
+	return reflect.Value{}
+}
+
+func (in *interp) ident(f *frame, obj types.Object) reflect.Value {
+	v, ok := obj.(*types.Var)
+	if !ok {
+		return reflect.Value{}
+	}
+
+	if holder, ok := f.lookup(v); ok {
+		return holder.vars[v]
+	}
+
+	if !isPackageVar(v) {
+		return reflect.Value{}
+	}
+
+	if known, ok := knownVars[qualifiedName(v)]; ok {
+		return known
+	}
+
+	if value, ok := in.global[v]; ok {
+		return value
+	}
+
+	src, ok := in.vars[v]
+	if !ok {
+		return reflect.Value{}
+	}
+
+	in.global[v] = reflect.Value{} // a variable initialized from itself is unknown
+	value := in.eval(&frame{pkg: src.pkg}, src.value, nil)
+	in.global[v] = value
+
+	return value
+}
+
+// composite builds the value of a composite literal of a known type. The
+// fields of the literals of user types are kept, they may hold groups.
+func (in *interp) composite(f *frame, lit *ast.CompositeLit, want reflect.Type) reflect.Value {
+	t := f.reflectType(f.info().TypeOf(lit))
+	if t == nil {
+		t = want
+	}
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() == reflect.Interface {
+		for _, elt := range lit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if field, ok := f.info().Uses[keyIdent(kv)].(*types.Var); ok && field.IsField() {
+					in.fields[field] = in.eval(f, kv.Value, nil)
+				}
+			}
+		}
+		return reflect.Value{}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		v := reflect.New(t).Elem()
+		for i, elt := range lit.Elts {
+			field, value := reflect.Value{}, elt
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				field, value = v.FieldByName(keyIdent(kv).Name), kv.Value
+			} else if i < v.NumField() {
+				field = v.Field(i)
+			}
+
+			if field.IsValid() && field.CanSet() {
+				in.set(field, in.eval(f, value, field.Type()), value)
+			}
+		}
+		return v
+
+	case reflect.Slice:
+		v := reflect.MakeSlice(t, 0, len(lit.Elts))
+		for _, elt := range lit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+			}
+			elem := reflect.New(t.Elem()).Elem()
+			in.set(elem, in.eval(f, elt, t.Elem()), elt)
+			v = reflect.Append(v, elem)
+		}
+		return v
+
+	case reflect.Map:
+		v := reflect.MakeMap(t)
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+
+			key := in.eval(f, kv.Key, t.Key())
+			if !key.IsValid() {
+				in.warnf(kv.Key, "can't evaluate %s, the entry is skipped", in.source(kv.Key))
+				continue
+			}
+
+			elem := reflect.New(t.Elem()).Elem()
+			in.set(elem, in.eval(f, kv.Value, t.Elem()), kv.Value)
+			v.SetMapIndex(key.Convert(t.Key()), elem)
+		}
+		return v
+	}
+
+	return reflect.Value{}
+}
+
+func keyIdent(kv *ast.KeyValueExpr) *ast.Ident {
+	id, _ := kv.Key.(*ast.Ident)
+	if id == nil {
+		return &ast.Ident{}
+	}
+
+	return id
+}
+
+// set stores v in dst, or warns and leaves dst zero when v is unknown.
+func (in *interp) set(dst, v reflect.Value, expr ast.Expr) {
+	v = convert(v, dst.Type())
+	if !v.IsValid() {
+		in.warnf(expr, "can't evaluate %s, its zero value is used", in.source(expr))
+		return
+	}
+
+	dst.Set(v)
+}
+
+func convert(v reflect.Value, want reflect.Type) reflect.Value {
+	switch {
+	case !v.IsValid() || want == nil:
+		return v
+	case v.Type().AssignableTo(want):
+		return v
+	case v.Kind() == reflect.Interface && !v.IsNil():
+		return convert(v.Elem(), want)
+	case v.Type().ConvertibleTo(want) && v.Kind() != reflect.Interface && want.Kind() != reflect.String:
+		return v.Convert(want)
+	case v.Kind() == reflect.String && want.Kind() == reflect.String:
+		return v.Convert(want)
+	}
+
+	return reflect.Value{}
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+
+	return v
+}
+
+func isStatic(v reflect.Value) bool {
+	return v.IsValid() && v.Type() == reflect.TypeFor[generator.StaticType]()
+}
+
+// isUserValue reports whether the values of t are documented as a
+// generator.StaticType when they can't be built.
+func isUserValue(t types.Type) bool {
+	switch t.Underlying().(type) {
+	case *types.Interface, *types.Signature, *types.Chan, *types.Tuple:
+		return false
+	}
+
+	return true
+}
+
+// call follows a call: the goswag methods and helpers are called, the
+// functions of the module are followed and the others are ignored.
+func (in *interp) call(f *frame, call *ast.CallExpr) []reflect.Value {
+	info := f.info()
+
+	if tv := info.Types[call.Fun]; tv.IsType() {
+		if len(call.Args) != 1 {
+			return nil
+		}
+		t := f.reflectType(tv.Type)
+		return []reflect.Value{in.eval(f, call.Args[0], t)}
+	}
+
+	if tv := info.Types[call.Fun]; tv.IsBuiltin() {
+		return in.builtin(f, call)
+	}
+
+	if lit, ok := ast.Unparen(call.Fun).(*ast.FuncLit); ok {
+		return in.callLit(f, f, lit, call)
+	}
+
+	switch callee := typeutil.Callee(info, call).(type) {
+	case *types.Func:
+		// the receiver is evaluated once, it may register routes
+		var recv reflect.Value
+		if sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr); ok && isMethodCall(info, sel) {
+			recv = in.eval(f, sel.X, nil)
+			if isWrapper(recv) {
+				return in.callWrapper(f, recv, sel.Sel.Name, call)
+			}
+		}
+
+		if fn, ok := knownFuncs[qualifiedName(callee)]; ok && callee.Signature().Recv() == nil {
+			return in.callKnown(f, fn, qualifiedName(callee), call)
+		}
+
+		if src, ok := in.funcs[callee.Origin()]; ok {
+			return in.callSource(f, src, recv, call)
+		}
+
+	case *types.Var:
+		ref, ok := f.funcRef(callee)
+		if !ok {
+			return nil
+		}
+		if lit, ok := ast.Unparen(ref.expr).(*ast.FuncLit); ok {
+			return in.callLit(ref.frame, f, lit, call)
+		}
+	}
+
+	return nil
+}
+
+func (in *interp) builtin(f *frame, call *ast.CallExpr) []reflect.Value {
+	id, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok || id.Name != "append" || len(call.Args) == 0 {
+		return nil
+	}
+
+	s := in.eval(f, call.Args[0], nil)
+	if !s.IsValid() || s.Kind() != reflect.Slice {
+		return nil
+	}
+
+	for _, arg := range call.Args[1:] {
+		if call.Ellipsis.IsValid() {
+			more := in.eval(f, arg, s.Type())
+			if more.IsValid() {
+				s = reflect.AppendSlice(s, more)
+			}
+			continue
+		}
+
+		elem := reflect.New(s.Type().Elem()).Elem()
+		in.set(elem, in.eval(f, arg, s.Type().Elem()), arg)
+		s = reflect.Append(s, elem)
+	}
+
+	return []reflect.Value{s}
+}
+
+// isWrapper reports whether v is a goswag instance, group or route.
+func isWrapper(v reflect.Value) bool {
+	if !v.IsValid() || (v.Kind() == reflect.Interface && v.IsNil()) {
+		return false
+	}
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+
+	t := v.Type()
+	return t.Kind() == reflect.Pointer && strings.HasPrefix(t.Elem().PkgPath(), frameworksPath)
+}
+
+// callWrapper replays a method call on a goswag instance, group or route.
+// The handlers are replaced by placeholders, and the routes are named after
+// the handler of the source.
+func (in *interp) callWrapper(f *frame, recv reflect.Value, name string, call *ast.CallExpr) (results []reflect.Value) {
+	switch name {
+	case "GenerateSwagger", "GenerateSwaggerWith":
+		if gen, ok := recv.Interface().(Generator); ok {
+			in.generated = gen
+			in.options = in.outputOptions(f, call)
+		}
+		return nil
+	case "GenerateOpenAPI", "WriteTo", "Validate":
+		return nil
+	}
+
+	method := recv.MethodByName(name)
+	if !method.IsValid() {
+		return nil
+	}
+
+	args, handler, ok := in.args(f, method.Type(), name, call)
+	if !ok {
+		return nil
+	}
+
+	defer func() {
+		// the routers panic on the paths they reject, as they would at runtime
+		if r := recover(); r != nil {
+			in.warnf(call, "%s failed: %v", in.source(call.Fun), r)
+			results = nil
+		}
+	}()
+
+	results = in.invoke(method, args, call)
+
+	if handler != nil && len(results) > 0 {
+		if route, ok := results[0].Interface().(shared.StaticRoute); ok {
+			route.SetHandler(in.handlerName(f, handler))
+			route.SetSource(in.position(call))
+			in.reportLoops()
+		}
+	}
+
+	return results
+}
+
+// outputOptions evaluates the options of a GenerateSwaggerWith call. The
+// output ones are returned, with the output dir relative to the folder the
+// source runs in; the others only apply when the source is run.
+func (in *interp) outputOptions(f *frame, call *ast.CallExpr) []generator.Option {
+	if call.Ellipsis.IsValid() {
+		in.warnf(call, "can't evaluate the options of %s, they are not applied", in.source(call.Fun))
+		return nil
+	}
+
+	var opts []generator.Option
+	for _, arg := range call.Args {
+		var fn *types.Func
+		c, _ := ast.Unparen(arg).(*ast.CallExpr)
+		if c != nil && len(c.Args) == 1 {
+			fn, _ = typeutil.Callee(f.info(), c).(*types.Func)
+		}
+		if fn == nil {
+			in.warnf(arg, "can't evaluate %s, the option is not applied", in.source(arg))
+			continue
+		}
+
+		name := qualifiedName(fn)
+		option, ok := outputOptions[name]
+		if !ok {
+			in.warnf(arg, "%s only applies when the source is run", in.source(arg))
+			continue
+		}
+
+		v := in.eval(f, c.Args[0], reflect.TypeFor[string]())
+		if !v.IsValid() || v.Kind() != reflect.String {
+			in.warnf(arg, "can't evaluate %s, the option is not applied", in.source(arg))
+			continue
+		}
+
+		value := v.String()
+		if name == goswagPath+".WithOutputDir" && !filepath.IsAbs(value) {
+			value = filepath.Join(in.dir, value)
+		}
+		opts = append(opts, option(value))
+	}
+
+	return opts
+}
+
+// callKnown calls one of the knownFuncs.
+func (in *interp) callKnown(f *frame, fn reflect.Value, name string, call *ast.CallExpr) []reflect.Value {
+	args, _, ok := in.args(f, fn.Type(), name, call)
+	if !ok {
+		return nil
+	}
+
+	results := in.invoke(fn, args, call)
+
+	if len(results) > 0 {
+		if gen, ok := results[0].Interface().(Generator); ok {
+			in.instances = append(in.instances, gen)
+		}
+	}
+
+	return results
+}
+
+func (in *interp) invoke(fn reflect.Value, args []reflect.Value, call *ast.CallExpr) []reflect.Value {
+	if call.Ellipsis.IsValid() {
+		return fn.CallSlice(args)
+	}
+
+	return fn.Call(args)
+}
+
+// args evaluates the arguments of a call of a function of type ft. The
+// handlers are replaced by a placeholder, and the route one is returned.
+// It is not ok when the call must be skipped.
+func (in *interp) args(f *frame, ft reflect.Type, name string, call *ast.CallExpr) (args []reflect.Value, handler ast.Expr, ok bool) {
+	numIn := ft.NumIn()
+	placeholder := false
+
+	for i, arg := range call.Args {
+		variadic := ft.IsVariadic() && i >= numIn-1
+
+		var pt reflect.Type
+		switch {
+		case i >= numIn && !ft.IsVariadic():
+			return nil, nil, false
+		case variadic && call.Ellipsis.IsValid():
+			pt = ft.In(numIn - 1)
+		case variadic:
+			pt = ft.In(numIn - 1).Elem()
+		default:
+			pt = ft.In(i)
+		}
+
+		switch pt {
+		case echoHandlerType, ginHandlerType:
+			// with gin, the handlers are variadic: the last one is the route
+			// one and the others are middlewares
+			handler = arg
+			if !variadic || !placeholder {
+				args = append(args, reflect.Zero(pt))
+				placeholder = true
+			}
+			continue
+		case echoMiddlewareType, reflect.SliceOf(echoMiddlewareType), reflect.SliceOf(ginHandlerType):
+			continue
+		}
+
+		v := in.eval(f, arg, pt)
+
+		if isStatic(v) && paramsMethods[name] {
+			v = reflect.Zero(pt) // no params, like a value that is not a struct
+			if params := f.paramsType(f.info().TypeOf(arg)); params != nil {
+				v = reflect.Zero(params)
+			}
+		}
+
+		if isStatic(v) && skippedWithStatic[name] {
+			in.warnf(arg, "%s needs a value of %s, which can't be built from the source: the call is skipped", name, v.Interface().(generator.StaticType).Name)
+			return nil, nil, false
+		}
+
+		if !v.IsValid() && pt == ginEngineType {
+			v = reflect.ValueOf(gin.New())
+		}
+
+		if v = convert(v, pt); !v.IsValid() {
+			in.warnf(arg, "can't evaluate %s, its zero value is used", in.source(arg))
+			v = reflect.Zero(pt)
+		}
+
+		args = append(args, v)
+	}
+
+	if ft.IsVariadic() && call.Ellipsis.IsValid() && len(args) < numIn {
+		args = append(args, reflect.Zero(ft.In(numIn-1)))
+	}
+
+	for len(args) < numIn-boolToInt(ft.IsVariadic()) {
+		// a handler or a middleware passed with ... ends up here
+		args = append(args, reflect.Zero(ft.In(len(args))))
+	}
+
+	return args, handler, true
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// isMethodCall reports whether sel selects a method of a value, not a
+// function of a package or a method expression.
+func isMethodCall(info *types.Info, sel *ast.SelectorExpr) bool {
+	s, ok := info.Selections[sel]
+	return ok && s.Kind() == types.MethodVal
+}
+
+// callSource follows a call of a function declared in the module, with
+// recv as receiver for methods.
+func (in *interp) callSource(f *frame, src funcSource, recv reflect.Value, call *ast.CallExpr) []reflect.Value {
+	if in.depth >= maxDepth {
+		in.warnf(call, "calls nested too deeply, %s is not followed", in.source(call.Fun))
+		return nil
+	}
+
+	nf := &frame{pkg: src.pkg, decl: src.decl}
+	info := src.pkg.TypesInfo
+
+	if src.decl.Recv != nil && len(src.decl.Recv.List) > 0 {
+		if names := src.decl.Recv.List[0].Names; len(names) > 0 {
+			if obj := info.Defs[names[0]]; obj != nil {
+				nf.bind(obj, recv, nil, f)
+			}
+		}
+	}
+
+	if id := calleeIdent(call.Fun); id != nil {
+		if inst, ok := f.info().Instances[id]; ok {
+			if fn, ok := f.info().Uses[id].(*types.Func); ok {
+				nf.typeArgs = instanceTypeArgs(f, fn, inst)
+			}
+		}
+	}
+
+	in.bindParams(f, nf, info, src.decl.Type, call)
+
+	in.depth++
+	in.run(nf, src.decl.Body)
+	in.depth--
+
+	return nf.results
+}
+
+// callLit follows a call of a function literal declared in def.
+func (in *interp) callLit(def, f *frame, lit *ast.FuncLit, call *ast.CallExpr) []reflect.Value {
+	if in.depth >= maxDepth {
+		in.warnf(call, "calls nested too deeply, %s is not followed", in.source(call.Fun))
+		return nil
+	}
+
+	nf := &frame{pkg: def.pkg, decl: def.decl, parent: def}
+	in.bindParams(f, nf, def.info(), lit.Type, call)
+
+	in.depth++
+	in.run(nf, lit.Body)
+	in.depth--
+
+	return nf.results
+}
+
+// bindParams binds the parameters of the called function, in nf, to the
+// arguments of the call, evaluated in f.
+func (in *interp) bindParams(f, nf *frame, info *types.Info, ft *ast.FuncType, call *ast.CallExpr) {
+	var params []*ast.Ident
+	for _, field := range ft.Params.List {
+		params = append(params, field.Names...)
+	}
+
+	for i, name := range params {
+		obj := info.Defs[name]
+		if obj == nil {
+			continue
+		}
+
+		if i >= len(call.Args) {
+			nf.bind(obj, reflect.Value{}, nil, f)
+			continue
+		}
+
+		arg := call.Args[i]
+		if i == len(params)-1 && isVariadic(ft) && !call.Ellipsis.IsValid() {
+			nf.bind(obj, in.variadicArgs(f, nf, obj.Type(), call.Args[i:]), nil, f)
+			continue
+		}
+
+		nf.bind(obj, in.eval(f, arg, nf.reflectType(obj.Type())), arg, f)
+	}
+}
+
+func isVariadic(ft *ast.FuncType) bool {
+	if len(ft.Params.List) == 0 {
+		return false
+	}
+
+	_, ok := ft.Params.List[len(ft.Params.List)-1].Type.(*ast.Ellipsis)
+	return ok
+}
+
+// variadicArgs builds the slice of the variadic parameter of type t.
+func (in *interp) variadicArgs(f, nf *frame, t types.Type, args []ast.Expr) reflect.Value {
+	st := nf.reflectType(t)
+	if st == nil || st.Kind() != reflect.Slice {
+		return reflect.Value{}
+	}
+
+	s := reflect.MakeSlice(st, 0, len(args))
+	for _, arg := range args {
+		elem := reflect.New(st.Elem()).Elem()
+		in.set(elem, in.eval(f, arg, st.Elem()), arg)
+		s = reflect.Append(s, elem)
+	}
+
+	return s
+}
+
+func calleeIdent(fun ast.Expr) *ast.Ident {
+	switch e := ast.Unparen(fun).(type) {
+	case *ast.Ident:
+		return e
+	case *ast.SelectorExpr:
+		return e.Sel
+	case *ast.IndexExpr:
+		return calleeIdent(e.X)
+	case *ast.IndexListExpr:
+		return calleeIdent(e.X)
+	}
+
+	return nil
+}
+
+// instanceTypeArgs maps the type parameters of a generic function to the
+// type arguments of a call, resolved in the calling frame.
+func instanceTypeArgs(f *frame, fn *types.Func, inst types.Instance) map[*types.TypeParam]types.Type {
+	params := fn.Origin().Signature().TypeParams()
+	if params == nil {
+		return nil
+	}
+
+	args := make(map[*types.TypeParam]types.Type, params.Len())
+	for i := 0; i < params.Len() && i < inst.TypeArgs.Len(); i++ {
+		args[params.At(i)] = f.resolve(inst.TypeArgs.At(i))
+	}
+
+	return args
+}
+
+// source returns the source text of node, for warnings.
+func (in *interp) source(node ast.Node) string {
+	var b strings.Builder
+	_ = printer.Fprint(&b, in.fset, node)
+
+	return b.String()
+}
@@ -0,0 +1,218 @@
+package static
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strconv"
+
+	"github.com/diegoclair/goswag/internal/frameworks/shared"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// handlerName names the stub function of a route after its handler, the
+// way the adapters name it from the runtime name of the function, so the
+// stub doesn't change when switching to --static. It also returns the
+// runtime name, for the manifest. Handlers that can't be traced back to a
+// function get a generated function name and no runtime name.
+func (in *interp) handlerName(f *frame, expr ast.Expr) (funcName, name string) {
+	if name := in.runtimeName(f, expr); name != "" {
+		return shared.UniqueIdentifier(name), name
+	}
+
+	in.handlers++
+	return fmt.Sprintf("staticHandler%d", in.handlers), ""
+}
+
+// position returns where call is, as file:line, the way the adapters
+// record where a route is registered at runtime.
+func (in *interp) position(call *ast.CallExpr) string {
+	pos := in.fset.Position(call.Lparen)
+	return pos.Filename + ":" + strconv.Itoa(pos.Line)
+}
+
+// runtimeName returns the name the runtime gives the function expr
+// evaluates to, e.g. github.com/foo/api.(*Handler).getUser, or "" when it
+// is not known.
+func (in *interp) runtimeName(f *frame, expr ast.Expr) string {
+	info := f.info()
+
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		obj := info.Uses[e]
+		if ref, ok := f.funcRef(obj); ok {
+			return in.runtimeName(ref.frame, ref.expr)
+		}
+		if fn, ok := obj.(*types.Func); ok {
+			return funcName(fn)
+		}
+
+	case *ast.SelectorExpr:
+		if fn, ok := info.Uses[e.Sel].(*types.Func); ok {
+			return funcName(fn)
+		}
+
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		// an instantiated generic function
+		if id := calleeIdent(e); id != nil {
+			if fn, ok := info.Uses[id].(*types.Func); ok {
+				return funcName(fn)
+			}
+		}
+
+	case *ast.FuncLit:
+		if f.decl != nil {
+			return qualifier(f.pkg.Types) + "." + declName(f.decl) + closureSuffix(f.decl.Body, e)
+		}
+
+	case *ast.CallExpr:
+		// a handler factory: the handler is the closure it returns
+		fn := typeutil.StaticCallee(info, e)
+		if fn == nil {
+			return ""
+		}
+
+		src, ok := in.funcs[fn.Origin()]
+		if !ok {
+			return ""
+		}
+
+		if lit := returnedFuncLit(src.decl); lit != nil {
+			return qualifier(src.pkg.Types) + "." + declName(src.decl) + closureSuffix(src.decl.Body, lit)
+		}
+	}
+
+	return ""
+}
+
+// qualifier is the package part of the runtime names: its path, or main.
+func qualifier(pkg *types.Package) string {
+	if pkg.Name() == "main" {
+		return "main"
+	}
+
+	return pkg.Path()
+}
+
+func funcName(fn *types.Func) string {
+	fn = fn.Origin()
+	if fn.Pkg() == nil {
+		return ""
+	}
+
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return qualifier(fn.Pkg()) + "." + fn.Name()
+	}
+
+	return qualifier(fn.Pkg()) + "." + recvName(recv.Type()) + "." + fn.Name()
+}
+
+// recvName renders a receiver type the way runtime names do: T or (*T).
+func recvName(t types.Type) string {
+	ptr, isPtr := t.(*types.Pointer)
+	if isPtr {
+		t = ptr.Elem()
+	}
+
+	name := "?"
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		name = named.Obj().Name()
+	}
+
+	if isPtr {
+		return "(*" + name + ")"
+	}
+
+	return name
+}
+
+// declName is the name of a declared function within its package, with its
+// receiver for methods: getUser, (*Handler).getUser.
+func declName(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return decl.Name.Name
+	}
+
+	recv := decl.Recv.List[0].Type
+	ptr := false
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv, ptr = star.X, true
+	}
+
+	switch r := recv.(type) {
+	case *ast.IndexExpr:
+		recv = r.X
+	case *ast.IndexListExpr:
+		recv = r.X
+	}
+
+	name := "?"
+	if id, ok := recv.(*ast.Ident); ok {
+		name = id.Name
+	}
+
+	if ptr {
+		return "(*" + name + ")." + decl.Name.Name
+	}
+
+	return name + "." + decl.Name.Name
+}
+
+// closureSuffix returns the suffix the compiler gives the name of a closure
+// of body: .func2 for the second one, .func2.1 for the first one declared
+// in it.
+func closureSuffix(body *ast.BlockStmt, target *ast.FuncLit) string {
+	name, _ := findClosure(body, target, ".func")
+	return name
+}
+
+func findClosure(node ast.Node, target *ast.FuncLit, prefix string) (string, bool) {
+	var (
+		count int
+		name  string
+		found bool
+	)
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		lit, ok := n.(*ast.FuncLit)
+		if found || !ok {
+			return !found
+		}
+
+		count++
+		litName := prefix + strconv.Itoa(count)
+
+		if lit == target {
+			name, found = litName, true
+		} else {
+			name, found = findClosure(lit.Body, target, litName+".")
+		}
+
+		return false // the closures of lit are numbered within it
+	})
+
+	return name, found
+}
+
+// returnedFuncLit returns the function literal returned by decl, if any.
+func returnedFuncLit(decl *ast.FuncDecl) *ast.FuncLit {
+	var lit *ast.FuncLit
+
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false // the returns of closures return from them
+		case *ast.ReturnStmt:
+			if len(n.Results) == 1 {
+				if l, ok := ast.Unparen(n.Results[0]).(*ast.FuncLit); ok && lit == nil {
+					lit = l
+				}
+			}
+		}
+
+		return lit == nil
+	})
+
+	return lit
+}
@@ -0,0 +1,190 @@
+// Package static builds the goswag instance of a stub generator from its
+// source, without running it. The calls made on goswag instances, groups and
+// routes are found with go/types, by following main and the functions of the
+// module it calls, and are replayed on real instances with the arguments
+// evaluated from the source: constants, literals of the goswag types and
+// calls of the goswag helpers. Bodies of user types, whose values can't be
+// built, become generator.StaticType.
+package static
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strings"
+
+	"github.com/diegoclair/goswag/internal/generator"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/tools/go/packages"
+)
+
+// Generator is the goswag instance built from the source: the goswag.Echo
+// or goswag.Gin the stub is generated with.
+type Generator interface {
+	GenerateSwaggerWith(opts ...generator.Option) error
+}
+
+// sourceGenerator is an instance built from the source, with the options
+// it is generated with when the source runs.
+type sourceGenerator struct {
+	Generator
+	opts []generator.Option
+}
+
+func (g sourceGenerator) GenerateSwaggerWith(opts ...generator.Option) error {
+	return g.Generator.GenerateSwaggerWith(append(slices.Clone(g.opts), opts...)...)
+}
+
+// Warning reports a call or an argument the extraction had to skip, so the
+// stub may lack something the generator would have documented when run.
+type Warning struct {
+	Pos token.Position
+	Msg string
+}
+
+func (w Warning) String() string {
+	return w.Pos.String() + ": " + w.Msg
+}
+
+// generatedHeader starts the stubs written by goswag.
+const generatedHeader = "// Code generated by goswag. DO NOT EDIT."
+
+const loadMode = packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+	packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports
+
+// Extract builds the goswag instance of the main package in dir: the one
+// GenerateSwagger is called on or, when there is no such call, the first one
+// created. Only the packages of the module of dir are read; the calls into
+// other modules are not followed.
+//
+// The instance writes its stub where running the source in dir would: in
+// dir, or where the WithOutputDir, WithFileName and WithPackageName options
+// of its GenerateSwaggerWith call ask for. The options given to it are
+// applied after those.
+func Extract(dir string) (Generator, []Warning, error) {
+	mainPkg, pkgs, err := load(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mainDecl := findMain(mainPkg)
+	if mainDecl == nil {
+		return nil, nil, fmt.Errorf("no main function in %s", dir)
+	}
+
+	// the routes registered on the gin engines must not be printed
+	gin.SetMode(gin.ReleaseMode)
+
+	in := newInterp(mainPkg.Fset, pkgs)
+	in.dir = dir
+	in.run(&frame{pkg: mainPkg, decl: mainDecl}, mainDecl.Body)
+
+	opts := append([]generator.Option{generator.WithOutputDir(dir)}, in.options...)
+
+	switch {
+	case in.generated != nil:
+		return sourceGenerator{in.generated, opts}, in.warnings, nil
+	case len(in.instances) > 0:
+		return sourceGenerator{in.instances[0], opts}, in.warnings, nil
+	}
+
+	return nil, in.warnings, fmt.Errorf("no goswag instance created by the main function of %s", dir)
+}
+
+// load type checks the main package in dir and the packages of its module
+// it imports, directly or not.
+func load(dir string) (*packages.Package, []*packages.Package, error) {
+	cfg := &packages.Config{Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedModule, Dir: dir}
+
+	roots, err := packages.Load(cfg, ".")
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(roots) != 1 {
+		return nil, nil, fmt.Errorf("expected one package in %s, found %d", dir, len(roots))
+	}
+
+	root := roots[0]
+	if err := packageError(root); err != nil {
+		return nil, nil, err
+	}
+	if root.Name != "main" {
+		return nil, nil, fmt.Errorf("%s is package %s, not a main package", dir, root.Name)
+	}
+
+	paths := []string{root.PkgPath}
+	if root.Module != nil {
+		packages.Visit(roots, nil, func(p *packages.Package) {
+			if p != root && p.Module != nil && p.Module.Path == root.Module.Path {
+				paths = append(paths, p.PkgPath)
+			}
+		})
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: loadMode, Dir: dir, ParseFile: parseFile}, paths...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var mainPkg *packages.Package
+	for _, p := range pkgs {
+		if err := packageError(p); err != nil {
+			return nil, nil, err
+		}
+		if p.PkgPath == root.PkgPath {
+			mainPkg = p
+		}
+	}
+
+	if mainPkg == nil {
+		return nil, nil, errors.New("can't load the main package of " + dir)
+	}
+
+	return mainPkg, pkgs, nil
+}
+
+// parseFile parses the stubs written by goswag as empty files: a stale one
+// may not compile, e.g. after a handler is renamed, and it is the file being
+// regenerated.
+func parseFile(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+	if bytes.HasPrefix(src, []byte(generatedHeader)) {
+		file, err := parser.ParseFile(fset, filename, src, parser.PackageClauseOnly)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.File{Package: file.Package, Name: file.Name}, nil
+	}
+
+	return parser.ParseFile(fset, filename, src, parser.AllErrors|parser.ParseComments)
+}
+
+// packageError returns the first error of p. The failures of the go
+// command to compile p are left out: the ones that matter are also type
+// errors, and the stubs it compiles are not the ones type checked.
+func packageError(p *packages.Package) error {
+	for _, err := range p.Errors {
+		if err.Kind == packages.ListError && strings.HasPrefix(err.Msg, "# ") {
+			continue
+		}
+
+		return fmt.Errorf("loading %s: %v", p.PkgPath, err)
+	}
+
+	return nil
+}
+
+func findMain(p *packages.Package) *ast.FuncDecl {
+	for _, file := range p.Syntax {
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "main" {
+				return fn
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package static
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/diegoclair/goswag/internal/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The stubs in testdata/app are the ones written by `go run`: the static
+// ones must be the same, but for what is listed in warnings.
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name         string
+		dir          string
+		wantWarnings []string
+	}{
+		{
+			name: "Should extract the routes of echo, through methods, generics, handler factories and params structs",
+			dir:  filepath.Join("testdata", "app", "goswag"),
+		},
+		{
+			name: "Should extract the routes of gin, through closures, loops and variables",
+			dir:  filepath.Join("testdata", "app", "gin"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, warnings, err := Extract(tt.dir)
+			require.NoError(t, err)
+
+			output := t.TempDir()
+			err = gen.GenerateSwaggerWith(generator.WithOutputDir(output), generator.WithLogger(log.New(io.Discard, "", 0)))
+			require.NoError(t, err)
+
+			got, err := os.ReadFile(filepath.Join(output, "goswag.go"))
+			require.NoError(t, err)
+
+			want, err := os.ReadFile(filepath.Join(tt.dir, "goswag.go"))
+			require.NoError(t, err)
+
+			assert.Equal(t, string(want), string(got))
+
+			var gotWarnings []string
+			for _, w := range warnings {
+				gotWarnings = append(gotWarnings, w.Msg)
+			}
+			assert.Equal(t, tt.wantWarnings, gotWarnings)
+		})
+	}
+}
+
+// The manifests in testdata/app are the ones written by `go run`: the
+// handlers and sources found in the source must be the runtime ones.
+func TestExtract_manifest(t *testing.T) {
+	tests := []struct {
+		name string
+		dir  string
+	}{
+		{
+			name: "Should find the handlers and sources of echo routes",
+			dir:  filepath.Join("testdata", "app", "goswag"),
+		},
+		{
+			name: "Should find the handlers and sources of gin routes",
+			dir:  filepath.Join("testdata", "app", "gin"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, _, err := Extract(tt.dir)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			err = gen.GenerateSwaggerWith(generator.WithManifest(&buf), generator.WithLogger(log.New(io.Discard, "", 0)))
+			require.NoError(t, err)
+
+			var got, want generator.Manifest
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+
+			data, err := os.ReadFile(filepath.Join(tt.dir, "manifest.json"))
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(data, &want))
+
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestExtract_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		dir     string
+		wantErr string
+	}{
+		{
+			name:    "Should fail on packages that are not main",
+			dir:     filepath.Join("testdata", "app", "dto"),
+			wantErr: "not a main package",
+		},
+		{
+			name:    "Should fail when main creates no goswag instance",
+			dir:     filepath.Join("testdata", "app", "noinstance"),
+			wantErr: "no goswag instance",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Extract(tt.dir)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestExtract_staleStub(t *testing.T) {
+	gen, warnings, err := Extract(filepath.Join("testdata", "app", "stale"))
+	require.NoError(t, err, "a stub that doesn't compile must not prevent its regeneration")
+	require.NotNil(t, gen)
+	assert.Empty(t, warnings)
+}
+
+func TestExtract_options(t *testing.T) {
+	dir := filepath.Join("testdata", "app", "options")
+
+	gen, warnings, err := Extract(dir)
+	require.NoError(t, err)
+
+	var cfg generator.Config
+	for _, opt := range gen.(sourceGenerator).opts {
+		opt(&cfg)
+	}
+	assert.Equal(t, filepath.Join(dir, "docs"), cfg.OutputDir, "the output dir is relative to the folder the source runs in")
+	assert.Equal(t, "api.go", cfg.FileName)
+	assert.Empty(t, cfg.PackageName, "an option that can't be evaluated is not applied")
+
+	var gotWarnings []string
+	for _, w := range warnings {
+		gotWarnings = append(gotWarnings, fmt.Sprintf("%d: %s", w.Pos.Line, w.Msg))
+	}
+	assert.Equal(t, []string{
+		"20: routes are registered in this loop, whose iterations can't be evaluated: it is run once, so the ones of its other iterations are missing",
+		"24: routes are registered in this loop, whose iterations can't be evaluated: it is run once, so the ones of its other iterations are missing",
+		"32: can't evaluate goswag.WithPackageName(os.Getenv(\"PACKAGE\")), the option is not applied",
+		"33: goswag.WithLogger(log.Default()) only applies when the source is run",
+	}, gotWarnings)
+}
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"mime/multipart"
+	"time"
+)
+
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Error struct {
+	Message string `json:"message"`
+}
+
+type Page[T any] struct {
+	Items []T  `json:"items"`
+	Next  *int `json:"next"`
+}
+
+type Status string
+
+type Paging struct {
+	Page int `query:"page" default:"1"`
+	Size int `query:"size" maximum:"100"`
+}
+
+type Search struct {
+	Paging
+
+	Query  string    `query:"q"`
+	Tags   []string  `query:"tags"`
+	Since  time.Time `query:"since"`
+	Status *Status   `query:"status" enums:"active,blocked"`
+	Tenant string    `header:"X-Tenant" binding:"required"`
+	cursor string    `query:"cursor"`
+}
+
+type Photo struct {
+	File    *multipart.FileHeader `form:"file" binding:"required"`
+	Caption string                `form:"caption" description:"shown under the photo"`
+}
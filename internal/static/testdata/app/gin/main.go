@@ -0,0 +1,48 @@
+package main
+
+import (
+	"net/http"
+
+	"example.com/app/dto"
+	"github.com/diegoclair/goswag"
+	"github.com/diegoclair/goswag/models"
+	"github.com/gin-gonic/gin"
+)
+
+func main() {
+	r := gin.New()
+	g := goswag.NewGin(r)
+
+	register := func(router models.GinRouter, path string, handler gin.HandlerFunc) models.Swagger {
+		return router.GET(path, auth, handler)
+	}
+
+	orders := g.Group("/orders")
+	for _, route := range []string{"/open"} {
+		register(orders, route, listOrders).Summary("List open orders")
+	}
+
+	get := getOrder
+	register(orders, "/:id", get).
+		Summary("Get an order").
+		PathParam("id", "order id", goswag.IntType, true, goswag.Minimum(1)).
+		Returns([]models.ReturnType{{StatusCode: http.StatusOK, Body: &dto.User{}}})
+
+	orders.POST("/:id/notes", addNote).
+		Summary("Add a note to an order").
+		PathParam("id", "order id", goswag.IntType, true).
+		Read(struct {
+			Text string `json:"text" binding:"required"`
+		}{}).
+		Returns([]models.ReturnType{{StatusCode: http.StatusCreated, Body: map[string]int{}}})
+
+	g.GenerateSwagger()
+}
+
+func auth(c *gin.Context) {}
+
+func listOrders(c *gin.Context) {}
+
+func getOrder(c *gin.Context) {}
+
+func addNote(c *gin.Context) {}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"example.com/app/dto"
+	"example.com/app/handlers"
+	"example.com/app/routes"
+	"github.com/diegoclair/goswag"
+	"github.com/diegoclair/goswag/models"
+	"github.com/labstack/echo/v4"
+)
+
+const version = "1.0.0"
+
+var defaultResponses = []models.ReturnType{
+	{StatusCode: http.StatusInternalServerError, Body: dto.Error{}},
+}
+
+func main() {
+	e := goswag.NewEcho(defaultResponses...)
+
+	e.SetInfo(goswag.Info{Title: "Users API", Version: version, BasePath: "/"})
+	e.AddSecurityScheme("bearer", goswag.SecurityScheme{Type: goswag.SecurityBearer})
+
+	e.GET("/health", health).Summary("Health check").Public()
+
+	api := e.Group("/api")
+	routes.New(api).Register(handlers.New())
+
+	if err := e.GenerateSwaggerWith(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func health(c echo.Context) error {
+	return nil
+}
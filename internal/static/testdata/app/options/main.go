@@ -0,0 +1,42 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/diegoclair/goswag"
+	"github.com/labstack/echo/v4"
+)
+
+const docsDir = "docs"
+
+func main() {
+	e := goswag.NewEcho()
+
+	for _, path := range []string{"/v1/ping", "/v2/ping"} {
+		e.GET(path, ping).Summary("Ping")
+	}
+
+	for i := 0; i < len(os.Args); i++ {
+		e.GET("/arg", ping).Summary("Arg")
+	}
+
+	for range map[string]bool{"a": true, "b": true} {
+		e.GET("/a", ping).Summary("Map")
+		e.GET("/b", ping).Summary("Map")
+	}
+
+	err := e.GenerateSwaggerWith(
+		goswag.WithOutputDir(docsDir),
+		goswag.WithFileName("api.go"),
+		goswag.WithPackageName(os.Getenv("PACKAGE")),
+		goswag.WithLogger(log.Default()),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func ping(c echo.Context) error {
+	return nil
+}
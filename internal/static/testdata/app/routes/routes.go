@@ -0,0 +1,58 @@
+package routes
+
+import (
+	"net/http"
+
+	"example.com/app/dto"
+	"example.com/app/handlers"
+	"github.com/diegoclair/goswag"
+	"github.com/diegoclair/goswag/models"
+	"github.com/labstack/echo/v4"
+)
+
+type Router struct {
+	users models.EchoGroup
+}
+
+func New(api models.EchoGroup) *Router {
+	return &Router{users: api.Group("/users").Security("bearer")}
+}
+
+func (r *Router) Register(h *handlers.Handler) {
+	r.users.GET("/:id", h.GetUser).
+		Summary("Get a user").
+		PathParam("id", "user id", goswag.StringType, true).
+		Returns([]models.ReturnType{{StatusCode: http.StatusOK, Body: dto.User{}}})
+
+	list[dto.User](r.users, h.ListUsers)
+
+	r.users.POST("", h.CreateUser).
+		Summary("Create a user").
+		Read(&dto.User{}).
+		QueryParam("role", "role of the user", goswag.StringType, false, goswag.Enum("admin", "user"), goswag.Default("user")).
+		Returns([]models.ReturnType{{StatusCode: http.StatusCreated, Body: dto.User{}, Description: "created"}})
+
+	r.users.PUT("/:id/avatar", handlers.Upload()).
+		Summary("Upload an avatar").
+		PathParam("id", "user id", goswag.StringType, true).
+		Read([]byte{})
+
+	r.users.GET("/search", func(c echo.Context) error { return nil }).
+		Summary("Search users").
+		Params(dto.Search{})
+
+	r.users.POST("/:id/photos", func(c echo.Context) error { return nil }).
+		Summary("Add a photo").
+		PathParam("id", "user id", goswag.StringType, true).
+		ReadForm(&dto.Photo{})
+}
+
+func list[T any](g models.EchoGroup, h echo.HandlerFunc) {
+	g.GET("", h).
+		Summary("List users").
+		Returns([]models.ReturnType{{
+			StatusCode:           http.StatusOK,
+			Body:                 dto.Page[T]{},
+			OverrideStructFields: map[string]any{"items": []T{}},
+		}})
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"github.com/diegoclair/goswag"
+	"github.com/labstack/echo/v4"
+)
+
+func main() {
+	e := goswag.NewEcho()
+	e.GET("/ping", ping).Summary("Ping")
+	e.GenerateSwagger()
+}
+
+func ping(c echo.Context) error {
+	return nil
+}
@@ -0,0 +1,476 @@
+package static
+
+import (
+	"go/constant"
+	"go/types"
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/diegoclair/goswag"
+	"github.com/diegoclair/goswag/internal/generator"
+	"github.com/diegoclair/goswag/models"
+	"github.com/gin-gonic/gin"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	goswagPath = "github.com/diegoclair/goswag"
+	modelsPath = goswagPath + "/models"
+
+	// frameworksPath prefixes the packages of the goswag instances, groups
+	// and routes, whose methods are replayed.
+	frameworksPath = goswagPath + "/internal/frameworks/"
+)
+
+var (
+	anyType   = reflect.TypeFor[any]()
+	errorType = reflect.TypeFor[error]()
+
+	echoHandlerType    = reflect.TypeFor[echo.HandlerFunc]()
+	echoMiddlewareType = reflect.TypeFor[echo.MiddlewareFunc]()
+	ginHandlerType     = reflect.TypeFor[gin.HandlerFunc]()
+	ginEngineType      = reflect.TypeFor[*gin.Engine]()
+
+	fileHeaderType = reflect.TypeFor[*multipart.FileHeader]()
+	timeType       = reflect.TypeFor[time.Time]()
+)
+
+// knownTypes are the types whose values are built from the source, by
+// qualified name. The goswag ones are aliases of these.
+var knownTypes = map[string]reflect.Type{
+	modelsPath + ".ReturnType":      reflect.TypeFor[models.ReturnType](),
+	modelsPath + ".ResponseHeader":  reflect.TypeFor[models.ResponseHeader](),
+	modelsPath + ".Info":            reflect.TypeFor[models.Info](),
+	modelsPath + ".Contact":         reflect.TypeFor[models.Contact](),
+	modelsPath + ".License":         reflect.TypeFor[models.License](),
+	modelsPath + ".ExternalDocs":    reflect.TypeFor[models.ExternalDocs](),
+	modelsPath + ".Tag":             reflect.TypeFor[models.Tag](),
+	modelsPath + ".SecurityScheme":  reflect.TypeFor[models.SecurityScheme](),
+	modelsPath + ".ParamAttributes": reflect.TypeFor[models.ParamAttributes](),
+	modelsPath + ".ParamOption":     reflect.TypeFor[models.ParamOption](),
+	"time.Time":                     reflect.TypeFor[time.Time](),
+	"time.Duration":                 reflect.TypeFor[time.Duration](),
+	"time.Month":                    reflect.TypeFor[time.Month](),
+	"time.Location":                 reflect.TypeFor[time.Location](),
+}
+
+// knownFuncs are the functions called when the source calls them, by
+// qualified name: the goswag constructors and helpers, and what their
+// arguments are usually built with.
+var knownFuncs = map[string]reflect.Value{
+	goswagPath + ".NewEcho":            reflect.ValueOf(goswag.NewEcho),
+	goswagPath + ".NewGin":             reflect.ValueOf(goswag.NewGin),
+	goswagPath + ".Enum":               reflect.ValueOf(models.Enum),
+	goswagPath + ".Default":            reflect.ValueOf(models.Default),
+	goswagPath + ".Example":            reflect.ValueOf(models.Example),
+	goswagPath + ".Minimum":            reflect.ValueOf(models.Minimum),
+	goswagPath + ".Maximum":            reflect.ValueOf(models.Maximum),
+	goswagPath + ".MinLength":          reflect.ValueOf(models.MinLength),
+	goswagPath + ".MaxLength":          reflect.ValueOf(models.MaxLength),
+	goswagPath + ".Pattern":            reflect.ValueOf(models.Pattern),
+	goswagPath + ".Format":             reflect.ValueOf(models.Format),
+	goswagPath + ".Items":              reflect.ValueOf(models.Items),
+	modelsPath + ".Enum":               reflect.ValueOf(models.Enum),
+	modelsPath + ".Default":            reflect.ValueOf(models.Default),
+	modelsPath + ".Example":            reflect.ValueOf(models.Example),
+	modelsPath + ".Minimum":            reflect.ValueOf(models.Minimum),
+	modelsPath + ".Maximum":            reflect.ValueOf(models.Maximum),
+	modelsPath + ".MinLength":          reflect.ValueOf(models.MinLength),
+	modelsPath + ".MaxLength":          reflect.ValueOf(models.MaxLength),
+	modelsPath + ".Pattern":            reflect.ValueOf(models.Pattern),
+	modelsPath + ".Format":             reflect.ValueOf(models.Format),
+	modelsPath + ".Items":              reflect.ValueOf(models.Items),
+	"github.com/gin-gonic/gin.New":     reflect.ValueOf(gin.New),
+	"github.com/gin-gonic/gin.Default": reflect.ValueOf(gin.Default),
+	"time.Date":                        reflect.ValueOf(time.Date),
+}
+
+// knownVars are the package variables read when the source reads them.
+var knownVars = map[string]reflect.Value{
+	"time.UTC":   reflect.ValueOf(time.UTC),
+	"time.Local": reflect.ValueOf(time.Local),
+}
+
+func qualifiedName(obj types.Object) string {
+	if obj.Pkg() == nil {
+		return obj.Name()
+	}
+
+	return obj.Pkg().Path() + "." + obj.Name()
+}
+
+// reflectType returns the type of the values built for t, or nil when they
+// can't be built: the types of the user.
+func (f *frame) reflectType(t types.Type) reflect.Type {
+	switch t := t.(type) {
+	case *types.Alias:
+		return f.reflectType(types.Unalias(t))
+
+	case *types.TypeParam:
+		if arg := f.typeArg(t); arg != nil {
+			return f.reflectType(arg)
+		}
+
+	case *types.Named:
+		if t.Obj().Pkg() == nil && t.Obj().Name() == "error" {
+			return errorType
+		}
+		return knownTypes[qualifiedName(t.Obj())]
+
+	case *types.Basic:
+		return basicType(t)
+
+	case *types.Pointer:
+		if elem := f.reflectType(t.Elem()); elem != nil {
+			return reflect.PointerTo(elem)
+		}
+
+	case *types.Slice:
+		if elem := f.reflectType(t.Elem()); elem != nil {
+			return reflect.SliceOf(elem)
+		}
+
+	case *types.Map:
+		key, elem := f.reflectType(t.Key()), f.reflectType(t.Elem())
+		if key != nil && elem != nil {
+			return reflect.MapOf(key, elem)
+		}
+
+	case *types.Struct:
+		// anonymous structs, which the stub declares a type for, are built
+		// when their fields can be set the way the source sets them
+		fields := make([]reflect.StructField, 0, t.NumFields())
+		for i := range t.NumFields() {
+			field := t.Field(i)
+
+			ft := f.reflectType(field.Type())
+			if ft == nil || !field.Exported() || field.Embedded() {
+				return nil
+			}
+
+			fields = append(fields, reflect.StructField{Name: field.Name(), Type: ft, Tag: reflect.StructTag(t.Tag(i))})
+		}
+		return reflect.StructOf(fields)
+
+	case *types.Interface:
+		if t.Empty() {
+			return anyType
+		}
+	}
+
+	return nil
+}
+
+// paramsType returns a struct type whose fields have the tags of the fields
+// of the struct t and types documented the same way, or nil when t is not a
+// struct. The params of t can't be read from a value of a user type, so
+// Params and ReadForm are given the zero value of this one: generator.ParamsOf
+// finds the same params in it, with the same rules.
+func (f *frame) paramsType(t types.Type) reflect.Type {
+	return f.paramsStruct(t, make(map[*types.Struct]bool))
+}
+
+func (f *frame) paramsStruct(t types.Type, seen map[*types.Struct]bool) reflect.Type {
+	t = f.resolve(t)
+	for {
+		ptr, ok := t.Underlying().(*types.Pointer)
+		if !ok {
+			break
+		}
+		t = f.resolve(ptr.Elem())
+	}
+
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok || seen[st] {
+		return nil
+	}
+	seen[st] = true
+	defer delete(seen, st)
+
+	fields := make([]reflect.StructField, 0, st.NumFields())
+	for i := range st.NumFields() {
+		field := st.Field(i)
+		if !field.Exported() && !field.Embedded() {
+			continue
+		}
+
+		// the names don't matter, only the tags do: the unexported embedded
+		// fields are kept under an exported one, as ParamsOf reads them
+		sf := reflect.StructField{Name: "F" + strconv.Itoa(i), Type: f.paramType(field.Type()), Tag: reflect.StructTag(st.Tag(i))}
+		if field.Embedded() && sf.Type != timeType && sf.Type != fileHeaderType {
+			if embedded := f.paramsStruct(field.Type(), seen); embedded != nil {
+				sf.Type, sf.Anonymous = embedded, true
+			}
+		}
+
+		fields = append(fields, sf)
+	}
+
+	return reflect.StructOf(fields)
+}
+
+// paramType returns the type of the field of a params type standing for a
+// field of type t: the basic type it is made of, time.Time, a file or a slice
+// of those. The other types are documented as strings, so string stands for
+// them.
+func (f *frame) paramType(t types.Type) reflect.Type {
+	t = f.resolve(t)
+
+	switch t := t.(type) {
+	case *types.Pointer:
+		if named, ok := types.Unalias(t.Elem()).(*types.Named); ok && qualifiedName(named.Obj()) == "mime/multipart.FileHeader" {
+			return fileHeaderType
+		}
+		return f.paramType(t.Elem())
+	case *types.Named:
+		if qualifiedName(t.Obj()) == "time.Time" {
+			return timeType
+		}
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		if bt := basicType(u); bt != nil {
+			return bt
+		}
+	case *types.Slice:
+		return reflect.SliceOf(f.paramType(u.Elem()))
+	case *types.Array:
+		return reflect.SliceOf(f.paramType(u.Elem()))
+	}
+
+	return reflect.TypeFor[string]()
+}
+
+// basicType returns the type of a basic type; the untyped ones get the
+// type an untyped constant gets when assigned to any.
+func basicType(t *types.Basic) reflect.Type {
+	switch t.Kind() {
+	case types.Bool, types.UntypedBool:
+		return reflect.TypeFor[bool]()
+	case types.Int, types.UntypedInt:
+		return reflect.TypeFor[int]()
+	case types.Int8:
+		return reflect.TypeFor[int8]()
+	case types.Int16:
+		return reflect.TypeFor[int16]()
+	case types.Int32, types.UntypedRune:
+		return reflect.TypeFor[int32]()
+	case types.Int64:
+		return reflect.TypeFor[int64]()
+	case types.Uint:
+		return reflect.TypeFor[uint]()
+	case types.Uint8:
+		return reflect.TypeFor[uint8]()
+	case types.Uint16:
+		return reflect.TypeFor[uint16]()
+	case types.Uint32:
+		return reflect.TypeFor[uint32]()
+	case types.Uint64:
+		return reflect.TypeFor[uint64]()
+	case types.Float32:
+		return reflect.TypeFor[float32]()
+	case types.Float64, types.UntypedFloat:
+		return reflect.TypeFor[float64]()
+	case types.String, types.UntypedString:
+		return reflect.TypeFor[string]()
+	}
+
+	return nil
+}
+
+// constValue returns the value of a constant of type t, converted to want
+// when it is set.
+func constValue(c constant.Value, t types.Type, want reflect.Type) reflect.Value {
+	var v reflect.Value
+
+	switch c.Kind() {
+	case constant.String:
+		v = reflect.ValueOf(constant.StringVal(c))
+	case constant.Bool:
+		v = reflect.ValueOf(constant.BoolVal(c))
+	case constant.Int:
+		i, _ := constant.Int64Val(c)
+		v = reflect.ValueOf(int(i))
+	case constant.Float:
+		f, _ := constant.Float64Val(c)
+		v = reflect.ValueOf(f)
+	default:
+		return reflect.Value{}
+	}
+
+	target := want
+	if target == nil || target.Kind() == reflect.Interface {
+		if basic, ok := t.Underlying().(*types.Basic); ok {
+			target = basicType(basic)
+		}
+	}
+
+	if target != nil && target.Kind() != reflect.Interface && v.Type().ConvertibleTo(target) {
+		return v.Convert(target)
+	}
+
+	return v
+}
+
+// staticType describes t for the stub, the way the generator describes
+// the types of the values it is given.
+func (f *frame) staticType(t types.Type) generator.StaticType {
+	packages := make(map[string]bool)
+
+	st := generator.StaticType{
+		Name:          f.typeName(t, packages, false),
+		QualifiedName: f.typeName(t, nil, true),
+	}
+
+	var elem types.Type
+	st.Kind, elem = f.typeKind(t)
+
+	switch st.Kind {
+	case "file":
+		st.Elem, st.QualifiedElem = "file", "file"
+	case "string":
+		st.Elem, st.QualifiedElem = "string", "string"
+	case "boolean":
+		st.Elem, st.QualifiedElem = "bool", "bool"
+	case "integer", "number":
+		st.Elem = basicType(elem.Underlying().(*types.Basic)).Name()
+		st.QualifiedElem = st.Elem
+	default:
+		st.Elem, st.QualifiedElem = f.typeName(elem, packages, false), f.typeName(elem, nil, true)
+	}
+
+	for pkg := range packages {
+		st.Packages = append(st.Packages, pkg)
+	}
+
+	return st
+}
+
+// typeName renders t the way swag expects it, e.g. dto.Page[dto.User], and
+// adds the packages it refers to to packages. The types of the main package
+// are not imported, the stub lives in it. With paths, the types are
+// qualified by the path of their package instead, the way reflect prints
+// them: example.com/app/dto.Page[example.com/app/dto.User].
+func (f *frame) typeName(t types.Type, packages map[string]bool, paths bool) string {
+	switch t := t.(type) {
+	case *types.Alias:
+		return f.typeName(types.Unalias(t), packages, paths)
+
+	case *types.TypeParam:
+		if arg := f.typeArg(t); arg != nil {
+			return f.typeName(arg, packages, paths)
+		}
+
+	case *types.Pointer:
+		return f.typeName(t.Elem(), packages, paths)
+
+	case *types.Named:
+		obj := t.Obj()
+		if obj.Pkg() == nil {
+			return obj.Name() // error
+		}
+
+		if obj.Pkg().Name() != "main" && packages != nil {
+			packages[obj.Pkg().Path()] = true
+		}
+
+		name := obj.Pkg().Name() + "." + obj.Name()
+		if paths {
+			// reflect names the main package main, whatever its path
+			name = qualifier(obj.Pkg()) + "." + obj.Name()
+		}
+
+		if t.TypeArgs().Len() == 0 {
+			return name
+		}
+
+		args := make([]string, 0, t.TypeArgs().Len())
+		for arg := range t.TypeArgs().Types() {
+			args = append(args, f.typeName(arg, packages, paths))
+		}
+
+		return name + "[" + strings.Join(args, ",") + "]"
+
+	case *types.Basic:
+		if rt := basicType(t); rt != nil {
+			return rt.Name()
+		}
+		return t.Name()
+
+	case *types.Slice:
+		return "[]" + f.typeName(t.Elem(), packages, paths)
+
+	case *types.Array:
+		return "[]" + f.typeName(t.Elem(), packages, paths)
+
+	case *types.Map:
+		return "map[" + f.typeName(t.Key(), packages, paths) + "]" + f.typeName(t.Elem(), packages, paths)
+	}
+
+	if paths {
+		return "interface {}"
+	}
+
+	return "interface{}"
+}
+
+// typeKind returns the schema kind of a response body of type t, and the
+// type written after it: the element of arrays, t itself otherwise.
+func (f *frame) typeKind(t types.Type) (kind string, elem types.Type) {
+	t = f.resolve(t)
+	for {
+		p, ok := t.Underlying().(*types.Pointer)
+		if !ok {
+			break
+		}
+		t = f.resolve(p.Elem())
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		if isByte(u.Elem()) {
+			return "file", t
+		}
+		return "array", u.Elem()
+
+	case *types.Array:
+		return "array", u.Elem()
+
+	case *types.Basic:
+		info := u.Info()
+		switch {
+		case info&types.IsString != 0:
+			return "string", t
+		case info&types.IsBoolean != 0:
+			return "boolean", t
+		case info&types.IsInteger != 0:
+			return "integer", t
+		case info&types.IsFloat != 0:
+			return "number", t
+		}
+	}
+
+	return "object", t
+}
+
+// resolve strips the aliases of t and replaces its type parameter by its
+// type argument.
+func (f *frame) resolve(t types.Type) types.Type {
+	t = types.Unalias(t)
+	if tp, ok := t.(*types.TypeParam); ok {
+		if arg := f.typeArg(tp); arg != nil {
+			return f.resolve(arg)
+		}
+	}
+
+	return t
+}
+
+func isByte(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.Uint8
+}
@@ -40,4 +40,39 @@ type EchoGroup interface {
 	//
 	// Group creates a new router group with prefix and optional group-level middleware.
 	Group(prefix string, m ...echo.MiddlewareFunc) EchoGroup
+
+	// Security requires the security scheme registered with AddSecurityScheme, with the
+	// oauth2 scopes it needs, on every route of the group that does not declare its own.
+	// Sub-groups inherit it unless they set their own.
+	Security(scheme string, scopes ...string) EchoGroup
+
+	// Tag replaces the default tag of the routes of the group, by default its prefixes without
+	// slashes, and documents it. Declared tags are listed in declaration order.
+	Tag(name, description string, externalDocs ExternalDocs) EchoGroup
+
+	// DefaultResponses adds responses to every route of the group, e.g. a 403 for an admin group.
+	// They are added after the ones of the route, then the ones of the parent groups.
+	DefaultResponses(responses ...ReturnType) EchoGroup
+
+	// HeaderParam documents a header param on every route of the group that does not declare
+	// a header param with the same name, e.g. the Authorization header.
+	HeaderParam(name, description, dataType string, required bool, opts ...ParamOption) EchoGroup
+
+	// Accepts sets the mime types accepted by the routes of the group that do not set theirs.
+	Accepts(accept ...string) EchoGroup
+
+	// Produces sets the mime types produced by the routes of the group that do not set theirs.
+	Produces(produce ...string) EchoGroup
+
+	// Hidden leaves every route of the group out of the docs, e.g. a debug or pprof group.
+	Hidden() EchoGroup
+
+	// Audience documents the routes of the group that declare no audience only in the docs of the
+	// given audiences, e.g. "internal", generated with goswag.WithAudience.
+	Audience(names ...string) EchoGroup
+
+	// Spec documents the routes of the group in a spec of their own, with its own info set with
+	// SetSpecInfo, instead of the default one, e.g. Spec("v1") and Spec("v2") for versioned APIs.
+	// Sub-groups inherit it unless they set their own.
+	Spec(name string) EchoGroup
 }
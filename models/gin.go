@@ -35,6 +35,40 @@ type GinRouter interface {
 
 	// HEAD is a shortcut for router.Handle("HEAD", path, handlers).
 	HEAD(path string, h ...gin.HandlerFunc) Swagger
+
+	// Security requires the security scheme registered with AddSecurityScheme, with the
+	// oauth2 scopes it needs, on every route of the router that does not declare its own.
+	Security(scheme string, scopes ...string) GinRouter
+
+	// Tag replaces the default tag of the routes of the router, by default the prefix of the group
+	// without slashes, and documents it. Declared tags are listed in declaration order.
+	Tag(name, description string, externalDocs ExternalDocs) GinRouter
+
+	// DefaultResponses adds responses to every route of the router, e.g. a 403 for an admin group.
+	// They are added after the ones of the route, then the ones of the instance.
+	DefaultResponses(responses ...ReturnType) GinRouter
+
+	// HeaderParam documents a header param on every route of the router that does not declare
+	// a header param with the same name, e.g. the Authorization header.
+	HeaderParam(name, description, dataType string, required bool, opts ...ParamOption) GinRouter
+
+	// Accepts sets the mime types accepted by the routes of the router that do not set theirs.
+	Accepts(accept ...string) GinRouter
+
+	// Produces sets the mime types produced by the routes of the router that do not set theirs.
+	Produces(produce ...string) GinRouter
+
+	// Hidden leaves every route of the router out of the docs, e.g. a debug or pprof group.
+	Hidden() GinRouter
+
+	// Audience documents the routes of the router that declare no audience only in the docs of the
+	// given audiences, e.g. "internal", generated with goswag.WithAudience.
+	Audience(names ...string) GinRouter
+
+	// Spec documents the routes of the router in a spec of their own, with its own info set with
+	// SetSpecInfo, instead of the default one, e.g. Spec("v1") and Spec("v2") for versioned APIs.
+	// On the instance, it is also the spec of the groups that don't set their own.
+	Spec(name string) GinRouter
 }
 
 type GinGroup interface {
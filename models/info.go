@@ -0,0 +1,39 @@
+package models
+
+// Info is the general information of the API. Set it with SetInfo instead of
+// writing the swag general API comments (@title, @version...) above main.
+type Info struct {
+	Title          string
+	Version        string
+	Description    string
+	TermsOfService string
+	Contact        Contact
+	License        License
+
+	// Host and BasePath are where the API is served, e.g. "api.example.com" and "/v1".
+	Host     string
+	BasePath string
+	// Schemes are the transfer protocols of the API: http, https, ws or wss.
+	Schemes []string
+
+	ExternalDocs ExternalDocs
+}
+
+// Contact is the contact information of the API.
+type Contact struct {
+	Name  string
+	URL   string
+	Email string
+}
+
+// License is the license of the API.
+type License struct {
+	Name string
+	URL  string
+}
+
+// ExternalDocs points to additional documentation.
+type ExternalDocs struct {
+	Description string
+	URL         string
+}
@@ -1,10 +1,31 @@
 package models
 
+import "time"
+
 type ReturnType struct {
 	StatusCode int
 	Body       any
 	// example: map[jsonFieldName]fieldType{}
 	OverrideStructFields map[string]any
+	// Description of the response. If empty, the status text of the code is used.
+	Description string
+	// Headers sent with the response, like Location or Retry-After.
+	Headers []ResponseHeader
+	// Default declares the catch-all "default" response, used for every status
+	// code not documented explicitly. StatusCode is ignored when it is set.
+	Default bool
+	// Examples are named example bodies of the response, e.g. {"admin": User{...}}.
+	// They are encoded as JSON and must match the type of Body.
+	Examples map[string]any
+}
+
+// ResponseHeader documents a header sent with a response.
+type ResponseHeader struct {
+	Name string
+	// Type is the data type of the header value: goswag.StringType, goswag.IntType...
+	// If empty, StringType is used.
+	Type        string
+	Description string
 }
 
 type Swagger interface {
@@ -14,7 +35,8 @@ type Swagger interface {
 	// If not set, the default value will be the same as the summary.
 	Description(description string) Swagger
 
-	// The name of group will be used as default if it is not empty and the tags are not defined.
+	// If not set, the tag of the group is used: the one set with Tag or, by default,
+	// the prefixes of the group and its parents without slashes, e.g. api/users.
 	Tags(tags ...string) Swagger
 
 	// The default value is json.
@@ -28,11 +50,24 @@ type Swagger interface {
 	Produces(produce ...string) Swagger
 
 	// Read is used to define the request body of the route.
+	// Slices are documented as arrays of their element type and primitives with their own type.
 	Read(data any) Swagger
 
+	// ReadWithOverrides is Read overriding fields of the body, like the OverrideStructFields of Returns.
+	// It is meant for bodies whose fields vary by route, e.g. a `Payload any` field:
+	// ReadWithOverrides(Command{}, map[string]any{"payload": CreateUser{}})
+	ReadWithOverrides(data any, overrides map[string]any) Swagger
+
+	// Example adds an example of the request body set with Read. It is encoded as JSON and must
+	// match the type of the body, so it can be a value of that type or a map with the same fields.
+	// Calling it more than once adds more examples.
+	Example(v any) Swagger
+
 	// Returns is used to define the return of the route.
 	// The first parameter is the status code.
 	// The second parameter is the body of the response.
+	// Slices are documented as {array}, primitives as {string}, {integer}, {number} or {boolean}
+	// and []byte as {file}, for file downloads.
 	// The third parameter is used to override the fields of the response body, it is is optional.
 	// Example:
 	// if you have a response body like this:
@@ -47,7 +82,11 @@ type Swagger interface {
 	// OverrideStructFields: map[string]interface{}{"data": SomeStruct{}}
 	// where the SomeStruct{} is the struct that you want to use to override the "data" field.
 	//
-	// It accepts generic structs as well, but only for the first struct, if you have more deep generic fields, it may not work.
+	// Fields of fields are overridden with dotted keys, the JSON names of each level:
+	// OverrideStructFields: map[string]interface{}{"data.items": []Order{}}
+	// Every key must be a JSON field of the body, the generation reports the ones that are not.
+	//
+	// It accepts generic structs as well, with any number of type parameters and nested generics.
 	//
 	// Example using generic struct:
 	//
@@ -65,16 +104,73 @@ type Swagger interface {
 
 	// QueryParam is used to define the query parameters of the route and if it is required or not.
 	// The dataType field should be one of the following options:
-	// goswag.StringType, goswag.IntType, goswag.NumberType, goswag.BoolType.
-	QueryParam(name, description, dataType string, required bool) Swagger
+	// goswag.StringType, goswag.IntType, goswag.NumberType, goswag.BoolType, goswag.ArrayType.
+	// The opts set enums, defaults, ranges, formats and the items of arrays, e.g.:
+	// QueryParam("status", "Status filter", goswag.ArrayType, false, goswag.Items(goswag.StringType, "multi"), goswag.Enum("open", "closed"))
+	QueryParam(name, description, dataType string, required bool, opts ...ParamOption) Swagger
 
 	// HeaderParam is used to define the header parameters of the route and if it is required or not.
 	// The dataType field should be one of the following options:
 	// goswag.StringType, goswag.IntType, goswag.NumberType, goswag.BoolType.
-	HeaderParam(name, description, dataType string, required bool) Swagger
+	HeaderParam(name, description, dataType string, required bool, opts ...ParamOption) Swagger
 
 	// PathParam is used to define the path parameters of the route and if it is required or not.
 	// The dataType field should be one of the following options:
 	// goswag.StringType, goswag.IntType, goswag.NumberType, goswag.BoolType.
-	PathParam(name, description, dataType string, required bool) Swagger
+	PathParam(name, description, dataType string, required bool, opts ...ParamOption) Swagger
+
+	// FormParam is used to define the form fields of the route and if they are required or not.
+	// The dataType field should be one of the following options:
+	// goswag.StringType, goswag.IntType, goswag.NumberType, goswag.BoolType.
+	// If no Accepts is set, the route accepts x-www-form-urlencoded, or multipart/form-data when it has a FileParam.
+	FormParam(name, description, dataType string, required bool, opts ...ParamOption) Swagger
+
+	// FileParam is used to define a multipart file upload field of the route.
+	FileParam(name, description string, required bool) Swagger
+
+	// ReadForm is used to define the form fields of the route from a struct.
+	// The fields are read from the `form` tags, the same ones used by gin's ShouldBind and echo's Bind.
+	// *multipart.FileHeader fields are file uploads and `binding:"required"` or `validate:"required"` marks a field as required.
+	ReadForm(data any) Swagger
+
+	// Params is used to define the path, query, header and form params of the route from a struct,
+	// the same one the handler binds into. The params are read from the tags:
+	// `param` (echo) and `uri` (gin) for path params, `query`, `header` and `form`.
+	// `form` fields are query params on GET, HEAD, DELETE and OPTIONS routes and form fields otherwise.
+	// Requiredness comes from `binding:"required"` or `validate:"required"`, and the swag tags
+	// enums, default, example, format, minimum, maximum, minLength and maxLength are honoured.
+	Params(data any) Swagger
+
+	// Security requires the security scheme registered with AddSecurityScheme to call the route,
+	// with the oauth2 scopes it needs. It overrides the security of the group and of the instance.
+	// Calling it more than once adds alternatives, any of them grants access.
+	Security(scheme string, scopes ...string) Swagger
+
+	// Public documents the route as not requiring any security, even if its group or the instance do.
+	Public() Swagger
+
+	// OperationID sets the unique id of the route, used by client generators to name its method.
+	// If not set, it is the name of the handler, e.g. handleGetUser.
+	OperationID(id string) Swagger
+
+	// Deprecated marks the route as deprecated, with the reason and the date it will be removed.
+	// Both are optional: pass "" and time.Time{} to omit them.
+	Deprecated(reason string, sunset time.Time) Swagger
+
+	// Extension adds a vendor extension to the route, e.g. Extension("x-internal", true).
+	// The x- prefix is added to the key if missing, and the value is written as JSON.
+	Extension(key string, value any) Swagger
+
+	// SkipDefaultResponses removes the default responses with the given status codes from the route,
+	// e.g. the 401 of a health check. Without codes, the route gets no default response at all.
+	// A default response is also skipped when the route declares a response with its status code.
+	SkipDefaultResponses(codes ...int) Swagger
+
+	// Hidden leaves the route out of the docs, e.g. health checks and debug endpoints.
+	Hidden() Swagger
+
+	// Audience documents the route only in the docs of the given audiences, e.g. "internal",
+	// generated with goswag.WithAudience. It overrides the audiences of the group and of the instance.
+	// Routes without audiences are documented for every audience.
+	Audience(names ...string) Swagger
 }
@@ -0,0 +1,108 @@
+package models
+
+// ParamAttributes are the optional constraints and metadata of a param.
+// They are set through the ParamOption functions and written as swag
+// attributes, e.g. Enums(a, b) default(a) minimum(1).
+type ParamAttributes struct {
+	Enum      []any
+	Default   any
+	Example   any
+	Minimum   *float64
+	Maximum   *float64
+	MinLength *int
+	MaxLength *int
+	// Pattern is only used by the native OpenAPI emitter, swag has no attribute for it.
+	Pattern string
+	// Format refines the data type: date-time, uuid, email...
+	Format string
+	// Items is the data type of the elements of an array param.
+	Items string
+	// CollectionFormat is how an array param is serialized: csv, ssv, tsv, pipes or multi.
+	CollectionFormat string
+}
+
+// ParamOption sets an optional attribute of a param.
+type ParamOption func(*ParamAttributes)
+
+// NewParamAttributes returns the attributes set by opts.
+func NewParamAttributes(opts ...ParamOption) ParamAttributes {
+	var attrs ParamAttributes
+	for _, opt := range opts {
+		opt(&attrs)
+	}
+
+	return attrs
+}
+
+// Enum restricts the param to the given values.
+func Enum(values ...any) ParamOption {
+	return func(a *ParamAttributes) {
+		a.Enum = values
+	}
+}
+
+// Default sets the value used by the server when the param is omitted.
+func Default(value any) ParamOption {
+	return func(a *ParamAttributes) {
+		a.Default = value
+	}
+}
+
+// Example sets an example value of the param.
+func Example(value any) ParamOption {
+	return func(a *ParamAttributes) {
+		a.Example = value
+	}
+}
+
+// Minimum sets the inclusive lower bound of a numeric param.
+func Minimum(value float64) ParamOption {
+	return func(a *ParamAttributes) {
+		a.Minimum = &value
+	}
+}
+
+// Maximum sets the inclusive upper bound of a numeric param.
+func Maximum(value float64) ParamOption {
+	return func(a *ParamAttributes) {
+		a.Maximum = &value
+	}
+}
+
+// MinLength sets the minimum length of a string param.
+func MinLength(length int) ParamOption {
+	return func(a *ParamAttributes) {
+		a.MinLength = &length
+	}
+}
+
+// MaxLength sets the maximum length of a string param.
+func MaxLength(length int) ParamOption {
+	return func(a *ParamAttributes) {
+		a.MaxLength = &length
+	}
+}
+
+// Pattern sets the regular expression a string param must match.
+func Pattern(pattern string) ParamOption {
+	return func(a *ParamAttributes) {
+		a.Pattern = pattern
+	}
+}
+
+// Format refines the data type of the param: date-time, uuid, email...
+func Format(format string) ParamOption {
+	return func(a *ParamAttributes) {
+		a.Format = format
+	}
+}
+
+// Items sets the data type of the elements of an array param and how the
+// array is serialized: csv (a,b), multi (?a=1&a=2), ssv, tsv or pipes.
+// An empty collectionFormat keeps swag's default, csv.
+func Items(dataType, collectionFormat string) ParamOption {
+	return func(a *ParamAttributes) {
+		a.Items = dataType
+		a.CollectionFormat = collectionFormat
+	}
+}
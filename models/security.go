@@ -0,0 +1,41 @@
+package models
+
+// Security scheme types.
+const (
+	SecurityAPIKey = "apiKey"
+	SecurityBasic  = "basic"
+	SecurityBearer = "bearer"
+	SecurityOAuth2 = "oauth2"
+)
+
+// OAuth2 flows, named as in swag.
+const (
+	OAuth2Implicit    = "implicit"
+	OAuth2Password    = "password"
+	OAuth2Application = "application" // client credentials
+	OAuth2AccessCode  = "accessCode"  // authorization code
+)
+
+// SecurityScheme describes how clients authenticate. Register it on the
+// instance with AddSecurityScheme and require it with Security.
+type SecurityScheme struct {
+	// Type is one of SecurityAPIKey, SecurityBasic, SecurityBearer or SecurityOAuth2.
+	Type        string
+	Description string
+
+	// In and Name locate the key of an apiKey scheme, e.g. "header" and "X-API-Key".
+	In   string
+	Name string
+
+	// BearerFormat hints the format of a bearer token, e.g. JWT.
+	// swag 2.0 has no bearer type, so bearer schemes are written as an
+	// Authorization header apiKey in the swag annotations.
+	BearerFormat string
+
+	// Flow, AuthorizationURL, TokenURL and Scopes describe an oauth2 scheme.
+	// Scopes maps each scope name to its description.
+	Flow             string
+	AuthorizationURL string
+	TokenURL         string
+	Scopes           map[string]string
+}
@@ -0,0 +1,13 @@
+package models
+
+// Tag documents a tag: a named set of routes, shown as a section by the
+// swagger UIs in the order the tags are declared.
+type Tag struct {
+	Name         string
+	Description  string
+	ExternalDocs ExternalDocs
+}
+
+// TagNamer returns the default tag of the routes of a group from the
+// prefixes of the group and of its parents, outermost first.
+type TagNamer func(prefixes []string) string